@@ -0,0 +1,401 @@
+package hsds_types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	usZIPPattern       = regexp.MustCompile(`^\d{5}(-\d{4})?$`)
+	usZIPDigits        = regexp.MustCompile(`^\d{9}$`)
+	caPostalPattern    = regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] \d[ABCEGHJ-NPRSTV-Z]\d$`)
+	caPostalCompact    = regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z]\d[ABCEGHJ-NPRSTV-Z]\d$`)
+	caPostalFSA        = regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z]$`)
+	caPostalLDU        = regexp.MustCompile(`^\d[ABCEGHJ-NPRSTV-Z]\d$`)
+	addressPunctuation = strings.NewReplacer(".", "", ",", " ")
+)
+
+// AddressComponents holds the parts of a free-text address, ready to pass to NewAddress
+type AddressComponents struct {
+	Address1      string
+	Address2      *string
+	City          string
+	StateProvince string
+	PostalCode    string
+	Country       string
+}
+
+// IsValidCountryCode reports whether code is an assigned ISO 3166-1 alpha-2 code
+func IsValidCountryCode(code string) bool {
+	return isoCountryCodes[strings.ToUpper(code)]
+}
+
+// IsValidUSState reports whether code is a USPS state, territory or military code
+func IsValidUSState(code string) bool {
+	_, ok := usStates[strings.ToUpper(code)]
+	return ok
+}
+
+// IsValidZIP reports whether zip is a 5-digit ZIP or ZIP+4 code
+func IsValidZIP(zip string) bool {
+	return usZIPPattern.MatchString(zip)
+}
+
+// IsValidCanadianProvince reports whether code is a Canada Post province or territory code
+func IsValidCanadianProvince(code string) bool {
+	_, ok := caProvinces[strings.ToUpper(code)]
+	return ok
+}
+
+// IsValidCanadianPostalCode reports whether code is a Canadian postal code in "A1A 1A1" form
+func IsValidCanadianPostalCode(code string) bool {
+	return caPostalPattern.MatchString(strings.ToUpper(code))
+}
+
+// ParseAddress splits a single-line address such as
+// "123 Main Street, Suite 200, Seattle, WA 98101" into its components.
+// defaultCountry is used when the country cannot be inferred from the input.
+func ParseAddress(raw, defaultCountry string) (*AddressComponents, error) {
+	var segments []string
+	for _, seg := range strings.Split(raw, ",") {
+		if seg = strings.Join(strings.Fields(seg), " "); seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("address is empty")
+	}
+
+	components := &AddressComponents{}
+
+	// Trailing country segment, e.g. ", USA"
+	if len(segments) > 1 {
+		if code, ok := lookupCountry(segments[len(segments)-1]); ok {
+			components.Country = code
+			segments = segments[:len(segments)-1]
+		}
+	}
+
+	// Trailing "[City] State Postal" segment
+	tokens := strings.Fields(segments[len(segments)-1])
+	segments = segments[:len(segments)-1]
+
+	if n := len(tokens); n > 0 {
+		if code, ok := lookupCountry(tokens[n-1]); ok && components.Country == "" && n > 2 {
+			components.Country = code
+			tokens = tokens[:n-1]
+		}
+	}
+
+	postal, country, tokens := extractPostalCode(tokens)
+	if postal == "" {
+		return nil, fmt.Errorf("could not find a postal code in address: %s", raw)
+	}
+	components.PostalCode = postal
+
+	state, stateCountry, tokens := extractStateProvince(tokens, country)
+	if state == "" {
+		return nil, fmt.Errorf("could not find a state or province in address: %s", raw)
+	}
+	components.StateProvince = state
+	if country == "" {
+		country = stateCountry
+	}
+	if components.Country == "" {
+		components.Country = country
+	}
+	if components.Country == "" {
+		components.Country = strings.ToUpper(defaultCountry)
+	}
+
+	// Anything left in the state segment is the city; otherwise it is the
+	// preceding comma-separated segment.
+	if len(tokens) > 0 {
+		if len(segments) == 0 {
+			street, city := splitStreetAndCity(tokens)
+			segments = append(segments, strings.Join(street, " "))
+			tokens = city
+		}
+		components.City = strings.Join(tokens, " ")
+	} else if len(segments) > 1 {
+		components.City = segments[len(segments)-1]
+		segments = segments[:len(segments)-1]
+	}
+	if components.City == "" || len(segments) == 0 || segments[0] == "" {
+		return nil, fmt.Errorf("could not find a street and city in address: %s", raw)
+	}
+
+	line1 := segments[0]
+	extra := segments[1:]
+	if len(extra) == 0 {
+		if street, unit := splitUnit(line1); unit != "" {
+			line1 = street
+			extra = []string{unit}
+		}
+	}
+	components.Address1 = line1
+	if len(extra) > 0 {
+		line2 := strings.Join(extra, ", ")
+		components.Address2 = &line2
+	}
+
+	return components, nil
+}
+
+// Normalize rewrites a US address to USPS Publication 28 conventions (upper
+// case, standard suffix, directional and unit abbreviations, two-letter state,
+// ZIP+4 hyphenation) and tidies Canadian provinces and postal codes. Addresses
+// in other countries only have their country code upper-cased.
+func (a *Address) Normalize() {
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+
+	switch a.Country {
+	case "US":
+		a.Address1 = normalizeUSStreet(a.Address1)
+		if a.Address2 != nil {
+			line2 := normalizeUSSecondary(*a.Address2)
+			a.Address2 = &line2
+		}
+		a.City = normalizeUpper(a.City)
+		if code := stateCode(a.StateProvince, usStates); code != "" {
+			a.StateProvince = code
+		}
+		zip := strings.ReplaceAll(strings.TrimSpace(a.PostalCode), " ", "")
+		if usZIPDigits.MatchString(zip) {
+			zip = zip[:5] + "-" + zip[5:]
+		}
+		a.PostalCode = zip
+	case "CA":
+		a.Address1 = normalizeUpper(a.Address1)
+		if a.Address2 != nil {
+			line2 := normalizeUpper(*a.Address2)
+			a.Address2 = &line2
+		}
+		a.City = normalizeUpper(a.City)
+		if code := stateCode(a.StateProvince, caProvinces); code != "" {
+			a.StateProvince = code
+		}
+		postal := strings.ToUpper(strings.ReplaceAll(a.PostalCode, " ", ""))
+		if caPostalCompact.MatchString(postal) {
+			postal = postal[:3] + " " + postal[3:]
+		}
+		a.PostalCode = postal
+	}
+}
+
+// Validate checks the country code and, for US and Canadian addresses, the
+// state/province and postal code formats
+func (a *Address) Validate() error {
+	if !IsValidCountryCode(a.Country) {
+		return fmt.Errorf("invalid country code %q: must be ISO 3166-1 alpha-2", a.Country)
+	}
+
+	switch strings.ToUpper(a.Country) {
+	case "US":
+		if !IsValidUSState(a.StateProvince) {
+			return fmt.Errorf("invalid US state code %q", a.StateProvince)
+		}
+		if !IsValidZIP(a.PostalCode) {
+			return fmt.Errorf("invalid ZIP code %q: must be 12345 or 12345-6789", a.PostalCode)
+		}
+	case "CA":
+		if !IsValidCanadianProvince(a.StateProvince) {
+			return fmt.Errorf("invalid Canadian province code %q", a.StateProvince)
+		}
+		if !IsValidCanadianPostalCode(a.PostalCode) {
+			return fmt.Errorf("invalid Canadian postal code %q: must be A1A 1A1", a.PostalCode)
+		}
+	}
+
+	return nil
+}
+
+// SingleLine formats the address on one line, e.g. "123 Main St, Apt 4, Seattle, WA 98101, US"
+func (a *Address) SingleLine() string {
+	parts := []string{a.Address1}
+	if a.Address2 != nil && *a.Address2 != "" {
+		parts = append(parts, *a.Address2)
+	}
+	if a.City != "" {
+		parts = append(parts, a.City)
+	}
+	if region := strings.TrimSpace(a.StateProvince + " " + a.PostalCode); region != "" {
+		parts = append(parts, region)
+	}
+	if a.Country != "" {
+		parts = append(parts, a.Country)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// lookupCountry resolves a country name or ISO code appearing at the end of an address
+func lookupCountry(s string) (string, bool) {
+	key := normalizeUpper(s)
+	if code, ok := countryNames[key]; ok {
+		return code, true
+	}
+	return "", false
+}
+
+// extractPostalCode removes a trailing US ZIP or Canadian postal code from
+// tokens and reports which country its format belongs to
+func extractPostalCode(tokens []string) (postal, country string, rest []string) {
+	n := len(tokens)
+	if n == 0 {
+		return "", "", tokens
+	}
+
+	last := strings.ToUpper(tokens[n-1])
+	switch {
+	case usZIPPattern.MatchString(last):
+		return last, "US", tokens[:n-1]
+	case usZIPDigits.MatchString(last):
+		return last[:5] + "-" + last[5:], "US", tokens[:n-1]
+	case caPostalCompact.MatchString(last):
+		return last[:3] + " " + last[3:], "CA", tokens[:n-1]
+	case n > 1 && caPostalLDU.MatchString(last) && caPostalFSA.MatchString(strings.ToUpper(tokens[n-2])):
+		return strings.ToUpper(tokens[n-2]) + " " + last, "CA", tokens[:n-2]
+	}
+
+	return "", "", tokens
+}
+
+// extractStateProvince removes a trailing state or province (code or full
+// name of up to four words) from tokens. When country is known only that
+// country's subdivisions are considered.
+func extractStateProvince(tokens []string, country string) (code, inferred string, rest []string) {
+	tables := []struct {
+		country string
+		names   map[string]string
+	}{
+		{"US", usStates},
+		{"CA", caProvinces},
+	}
+
+	for width := 4; width >= 1; width-- {
+		if width > len(tokens) {
+			continue
+		}
+		candidate := normalizeUpper(strings.Join(tokens[len(tokens)-width:], " "))
+		for _, table := range tables {
+			if country != "" && country != table.country {
+				continue
+			}
+			if code := stateCode(candidate, table.names); code != "" {
+				return code, table.country, tokens[:len(tokens)-width]
+			}
+		}
+	}
+
+	return "", "", tokens
+}
+
+// stateCode resolves a subdivision code or full name against a code-to-name table
+func stateCode(s string, names map[string]string) string {
+	key := normalizeUpper(s)
+	if _, ok := names[key]; ok {
+		return key
+	}
+	for code, name := range names {
+		if name == key {
+			return code
+		}
+	}
+	return ""
+}
+
+// splitStreetAndCity divides comma-less "123 Main St Apt 4 Seattle" tokens
+// after the last street suffix or unit number
+func splitStreetAndCity(tokens []string) (street, city []string) {
+	cut := -1
+	for i, tok := range tokens {
+		upper := normalizeUpper(tok)
+		if _, ok := streetSuffixes[upper]; ok && i > 0 {
+			cut = i + 1
+		}
+		if _, ok := unitDesignators[upper]; ok && cut >= 0 && i+1 < len(tokens) {
+			cut = i + 2
+		}
+	}
+	if cut <= 0 || cut >= len(tokens) {
+		return tokens, nil
+	}
+	return tokens[:cut], tokens[cut:]
+}
+
+// splitUnit separates a secondary unit ("Apt 4", "Suite 200", "#12") from a street line
+func splitUnit(line string) (street, unit string) {
+	tokens := strings.Fields(line)
+	for i := 1; i < len(tokens); i++ {
+		upper := normalizeUpper(tokens[i])
+		if _, ok := unitDesignators[upper]; ok && i+1 < len(tokens) {
+			return strings.Join(tokens[:i], " "), strings.Join(tokens[i:], " ")
+		}
+		if strings.HasPrefix(tokens[i], "#") && len(tokens[i]) > 1 {
+			return strings.Join(tokens[:i], " "), strings.Join(tokens[i:], " ")
+		}
+	}
+	return line, ""
+}
+
+// normalizeUSStreet applies USPS Publication 28 abbreviations to a delivery address line
+func normalizeUSStreet(line string) string {
+	tokens := strings.Fields(normalizeUpper(line))
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	// Secondary unit designator, everything after it is the unit number
+	unitAt := len(tokens)
+	for i := 1; i < len(tokens); i++ {
+		if abbr, ok := unitDesignators[tokens[i]]; ok && i+1 < len(tokens) {
+			tokens[i] = abbr
+			unitAt = i
+			break
+		}
+	}
+	street := tokens[:unitAt]
+
+	// Post-directional ends the street and the suffix precedes it
+	end := len(street)
+	if end > 2 {
+		if abbr, ok := directionals[street[end-1]]; ok {
+			street[end-1] = abbr
+			end--
+		}
+	}
+	if end > 1 {
+		if abbr, ok := streetSuffixes[street[end-1]]; ok {
+			street[end-1] = abbr
+			end--
+		}
+	}
+
+	// Pre-directional follows the house number, unless it is the street name
+	// itself ("500 North Street")
+	if end > 2 {
+		if abbr, ok := directionals[street[1]]; ok {
+			street[1] = abbr
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// normalizeUSSecondary normalizes an address line that may hold only a unit ("Suite 200")
+func normalizeUSSecondary(line string) string {
+	tokens := strings.Fields(normalizeUpper(line))
+	if len(tokens) > 1 {
+		if abbr, ok := unitDesignators[tokens[0]]; ok {
+			tokens[0] = abbr
+			return strings.Join(tokens, " ")
+		}
+	}
+	return normalizeUSStreet(line)
+}
+
+// normalizeUpper upper-cases s, drops periods and collapses whitespace
+func normalizeUpper(s string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(addressPunctuation.Replace(s))), " ")
+}
@@ -0,0 +1,338 @@
+package hsds_types
+
+// isoCountryCodes lists every officially assigned ISO 3166-1 alpha-2 code
+var isoCountryCodes = makeSet(
+	"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT", "AU", "AW", "AX", "AZ",
+	"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN", "BO", "BQ", "BR", "BS",
+	"BT", "BV", "BW", "BY", "BZ", "CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN",
+	"CO", "CR", "CU", "CV", "CW", "CX", "CY", "CZ", "DE", "DJ", "DK", "DM", "DO", "DZ", "EC", "EE",
+	"EG", "EH", "ER", "ES", "ET", "FI", "FJ", "FK", "FM", "FO", "FR", "GA", "GB", "GD", "GE", "GF",
+	"GG", "GH", "GI", "GL", "GM", "GN", "GP", "GQ", "GR", "GS", "GT", "GU", "GW", "GY", "HK", "HM",
+	"HN", "HR", "HT", "HU", "ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT", "JE", "JM",
+	"JO", "JP", "KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ", "LA", "LB", "LC",
+	"LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY", "MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK",
+	"ML", "MM", "MN", "MO", "MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ", "NA",
+	"NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ", "OM", "PA", "PE", "PF", "PG",
+	"PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT", "PW", "PY", "QA", "RE", "RO", "RS", "RU", "RW",
+	"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM", "SN", "SO", "SR", "SS",
+	"ST", "SV", "SX", "SY", "SZ", "TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO",
+	"TR", "TT", "TV", "TW", "TZ", "UA", "UG", "UM", "US", "UY", "UZ", "VA", "VC", "VE", "VG", "VI",
+	"VN", "VU", "WF", "WS", "YE", "YT", "ZA", "ZM", "ZW",
+)
+
+// countryNames maps ISO codes and common spellings of country names to ISO
+// 3166-1 alpha-2 codes
+var countryNames = map[string]string{
+	"US":                       "US",
+	"USA":                      "US",
+	"U S":                      "US",
+	"U S A":                    "US",
+	"UNITED STATES":            "US",
+	"UNITED STATES OF AMERICA": "US",
+	"CA":                       "CA",
+	"CAN":                      "CA",
+	"CANADA":                   "CA",
+	"MX":                       "MX",
+	"MEX":                      "MX",
+	"MEXICO":                   "MX",
+}
+
+// usStates maps USPS state, territory and military codes to their names
+var usStates = map[string]string{
+	"AL": "ALABAMA",
+	"AK": "ALASKA",
+	"AZ": "ARIZONA",
+	"AR": "ARKANSAS",
+	"CA": "CALIFORNIA",
+	"CO": "COLORADO",
+	"CT": "CONNECTICUT",
+	"DE": "DELAWARE",
+	"DC": "DISTRICT OF COLUMBIA",
+	"FL": "FLORIDA",
+	"GA": "GEORGIA",
+	"HI": "HAWAII",
+	"ID": "IDAHO",
+	"IL": "ILLINOIS",
+	"IN": "INDIANA",
+	"IA": "IOWA",
+	"KS": "KANSAS",
+	"KY": "KENTUCKY",
+	"LA": "LOUISIANA",
+	"ME": "MAINE",
+	"MD": "MARYLAND",
+	"MA": "MASSACHUSETTS",
+	"MI": "MICHIGAN",
+	"MN": "MINNESOTA",
+	"MS": "MISSISSIPPI",
+	"MO": "MISSOURI",
+	"MT": "MONTANA",
+	"NE": "NEBRASKA",
+	"NV": "NEVADA",
+	"NH": "NEW HAMPSHIRE",
+	"NJ": "NEW JERSEY",
+	"NM": "NEW MEXICO",
+	"NY": "NEW YORK",
+	"NC": "NORTH CAROLINA",
+	"ND": "NORTH DAKOTA",
+	"OH": "OHIO",
+	"OK": "OKLAHOMA",
+	"OR": "OREGON",
+	"PA": "PENNSYLVANIA",
+	"RI": "RHODE ISLAND",
+	"SC": "SOUTH CAROLINA",
+	"SD": "SOUTH DAKOTA",
+	"TN": "TENNESSEE",
+	"TX": "TEXAS",
+	"UT": "UTAH",
+	"VT": "VERMONT",
+	"VA": "VIRGINIA",
+	"WA": "WASHINGTON",
+	"WV": "WEST VIRGINIA",
+	"WI": "WISCONSIN",
+	"WY": "WYOMING",
+	"AS": "AMERICAN SAMOA",
+	"GU": "GUAM",
+	"MP": "NORTHERN MARIANA ISLANDS",
+	"PR": "PUERTO RICO",
+	"VI": "VIRGIN ISLANDS",
+	"FM": "FEDERATED STATES OF MICRONESIA",
+	"MH": "MARSHALL ISLANDS",
+	"PW": "PALAU",
+	"AA": "ARMED FORCES AMERICAS",
+	"AE": "ARMED FORCES EUROPE",
+	"AP": "ARMED FORCES PACIFIC",
+}
+
+// caProvinces maps Canada Post province and territory codes to their names
+var caProvinces = map[string]string{
+	"AB": "ALBERTA",
+	"BC": "BRITISH COLUMBIA",
+	"MB": "MANITOBA",
+	"NB": "NEW BRUNSWICK",
+	"NL": "NEWFOUNDLAND AND LABRADOR",
+	"NS": "NOVA SCOTIA",
+	"NT": "NORTHWEST TERRITORIES",
+	"NU": "NUNAVUT",
+	"ON": "ONTARIO",
+	"PE": "PRINCE EDWARD ISLAND",
+	"QC": "QUEBEC",
+	"SK": "SASKATCHEWAN",
+	"YT": "YUKON",
+}
+
+// streetSuffixes maps street suffixes and their common variants to the
+// USPS Publication 28 Appendix C1 standard abbreviation
+var streetSuffixes = map[string]string{
+	"ALLEY":      "ALY",
+	"ALLY":       "ALY",
+	"ANNEX":      "ANX",
+	"ARCADE":     "ARC",
+	"AVENUE":     "AVE",
+	"AV":         "AVE",
+	"AVEN":       "AVE",
+	"AVE":        "AVE",
+	"BAYOU":      "BYU",
+	"BEACH":      "BCH",
+	"BEND":       "BND",
+	"BLUFF":      "BLF",
+	"BOULEVARD":  "BLVD",
+	"BOUL":       "BLVD",
+	"BLVD":       "BLVD",
+	"BRANCH":     "BR",
+	"BRIDGE":     "BRG",
+	"BROOK":      "BRK",
+	"BYPASS":     "BYP",
+	"CANYON":     "CYN",
+	"CAPE":       "CPE",
+	"CAUSEWAY":   "CSWY",
+	"CENTER":     "CTR",
+	"CENTRE":     "CTR",
+	"CIRCLE":     "CIR",
+	"CIRCL":      "CIR",
+	"CIR":        "CIR",
+	"CLIFF":      "CLF",
+	"CLUB":       "CLB",
+	"COMMON":     "CMN",
+	"CORNER":     "COR",
+	"COURSE":     "CRSE",
+	"COURT":      "CT",
+	"CT":         "CT",
+	"COVE":       "CV",
+	"CREEK":      "CRK",
+	"CRESCENT":   "CRES",
+	"CROSSING":   "XING",
+	"DALE":       "DL",
+	"DAM":        "DM",
+	"DRIVE":      "DR",
+	"DRIV":       "DR",
+	"DR":         "DR",
+	"ESTATE":     "EST",
+	"ESTATES":    "ESTS",
+	"EXPRESSWAY": "EXPY",
+	"EXTENSION":  "EXT",
+	"FALLS":      "FLS",
+	"FERRY":      "FRY",
+	"FIELD":      "FLD",
+	"FIELDS":     "FLDS",
+	"FLAT":       "FLT",
+	"FORD":       "FRD",
+	"FOREST":     "FRST",
+	"FORGE":      "FRG",
+	"FORK":       "FRK",
+	"FORT":       "FT",
+	"FREEWAY":    "FWY",
+	"GARDEN":     "GDN",
+	"GARDENS":    "GDNS",
+	"GATEWAY":    "GTWY",
+	"GLEN":       "GLN",
+	"GREEN":      "GRN",
+	"GROVE":      "GRV",
+	"HARBOR":     "HBR",
+	"HAVEN":      "HVN",
+	"HEIGHTS":    "HTS",
+	"HIGHWAY":    "HWY",
+	"HWAY":       "HWY",
+	"HWY":        "HWY",
+	"HILL":       "HL",
+	"HILLS":      "HLS",
+	"HOLLOW":     "HOLW",
+	"ISLAND":     "IS",
+	"JUNCTION":   "JCT",
+	"KNOLL":      "KNL",
+	"LAKE":       "LK",
+	"LAKES":      "LKS",
+	"LANDING":    "LNDG",
+	"LANE":       "LN",
+	"LN":         "LN",
+	"LOOP":       "LOOP",
+	"MALL":       "MALL",
+	"MANOR":      "MNR",
+	"MEADOW":     "MDW",
+	"MEADOWS":    "MDWS",
+	"MILL":       "ML",
+	"MOTORWAY":   "MTWY",
+	"MOUNT":      "MT",
+	"MOUNTAIN":   "MTN",
+	"ORCHARD":    "ORCH",
+	"OVAL":       "OVAL",
+	"PARK":       "PARK",
+	"PARKWAY":    "PKWY",
+	"PKWY":       "PKWY",
+	"PASS":       "PASS",
+	"PATH":       "PATH",
+	"PIKE":       "PIKE",
+	"PINE":       "PNE",
+	"PINES":      "PNES",
+	"PLACE":      "PL",
+	"PL":         "PL",
+	"PLAIN":      "PLN",
+	"PLAINS":     "PLNS",
+	"PLAZA":      "PLZ",
+	"POINT":      "PT",
+	"PORT":       "PRT",
+	"PRAIRIE":    "PR",
+	"RANCH":      "RNCH",
+	"RIDGE":      "RDG",
+	"RIVER":      "RIV",
+	"ROAD":       "RD",
+	"RD":         "RD",
+	"ROUTE":      "RTE",
+	"ROW":        "ROW",
+	"RUN":        "RUN",
+	"SHORE":      "SHR",
+	"SHORES":     "SHRS",
+	"SKYWAY":     "SKWY",
+	"SPRING":     "SPG",
+	"SPRINGS":    "SPGS",
+	"SQUARE":     "SQ",
+	"SQ":         "SQ",
+	"STATION":    "STA",
+	"STREAM":     "STRM",
+	"STREET":     "ST",
+	"STR":        "ST",
+	"ST":         "ST",
+	"SUMMIT":     "SMT",
+	"TERRACE":    "TER",
+	"TER":        "TER",
+	"TRACE":      "TRCE",
+	"TRAIL":      "TRL",
+	"TRL":        "TRL",
+	"TUNNEL":     "TUNL",
+	"TURNPIKE":   "TPKE",
+	"UNION":      "UN",
+	"VALLEY":     "VLY",
+	"VIEW":       "VW",
+	"VILLAGE":    "VLG",
+	"VISTA":      "VIS",
+	"WALK":       "WALK",
+	"WAY":        "WAY",
+	"WELL":       "WL",
+	"WELLS":      "WLS",
+}
+
+// directionals maps compass directions to their USPS Publication 28 abbreviation
+var directionals = map[string]string{
+	"NORTH":     "N",
+	"SOUTH":     "S",
+	"EAST":      "E",
+	"WEST":      "W",
+	"NORTHEAST": "NE",
+	"NORTHWEST": "NW",
+	"SOUTHEAST": "SE",
+	"SOUTHWEST": "SW",
+	"N":         "N",
+	"S":         "S",
+	"E":         "E",
+	"W":         "W",
+	"NE":        "NE",
+	"NW":        "NW",
+	"SE":        "SE",
+	"SW":        "SW",
+}
+
+// unitDesignators maps secondary unit designators to the USPS Publication 28
+// Appendix C2 standard abbreviation
+var unitDesignators = map[string]string{
+	"APARTMENT":  "APT",
+	"APT":        "APT",
+	"BASEMENT":   "BSMT",
+	"BSMT":       "BSMT",
+	"BUILDING":   "BLDG",
+	"BLDG":       "BLDG",
+	"DEPARTMENT": "DEPT",
+	"DEPT":       "DEPT",
+	"FLOOR":      "FL",
+	"FL":         "FL",
+	"FRONT":      "FRNT",
+	"HANGAR":     "HNGR",
+	"LOBBY":      "LBBY",
+	"LOT":        "LOT",
+	"LOWER":      "LOWR",
+	"OFFICE":     "OFC",
+	"OFC":        "OFC",
+	"PENTHOUSE":  "PH",
+	"PH":         "PH",
+	"PIER":       "PIER",
+	"REAR":       "REAR",
+	"ROOM":       "RM",
+	"RM":         "RM",
+	"SLIP":       "SLIP",
+	"SPACE":      "SPC",
+	"SPC":        "SPC",
+	"STOP":       "STOP",
+	"SUITE":      "STE",
+	"STE":        "STE",
+	"TRAILER":    "TRLR",
+	"UNIT":       "UNIT",
+	"UPPER":      "UPPR",
+	"#":          "#",
+}
+
+// makeSet builds a lookup set from a list of strings
+func makeSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,121 @@
+package hsds_types
+
+import (
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		raw      string
+		country  string
+		want     AddressComponents
+		address2 string
+		wantErr  bool
+	}{
+		{
+			raw:      "123 Main Street, Suite 200, Seattle, WA 98101",
+			want:     AddressComponents{Address1: "123 Main Street", City: "Seattle", StateProvince: "WA", PostalCode: "98101", Country: "US"},
+			address2: "Suite 200",
+		},
+		{
+			raw:      "123 Main St Apt 4 Seattle WA 981011234",
+			want:     AddressComponents{Address1: "123 Main St", City: "Seattle", StateProvince: "WA", PostalCode: "98101-1234", Country: "US"},
+			address2: "Apt 4",
+		},
+		{
+			raw:  "100 Queen St W, Toronto, ON M5H 2N2, CA",
+			want: AddressComponents{Address1: "100 Queen St W", City: "Toronto", StateProvince: "ON", PostalCode: "M5H 2N2", Country: "CA"},
+		},
+		{
+			raw:  "100 Queen St W, Toronto, Ontario M5H2N2, Canada",
+			want: AddressComponents{Address1: "100 Queen St W", City: "Toronto", StateProvince: "ON", PostalCode: "M5H 2N2", Country: "CA"},
+		},
+		{
+			raw:  "1 Market St, San Francisco, CA 94105, USA",
+			want: AddressComponents{Address1: "1 Market St", City: "San Francisco", StateProvince: "CA", PostalCode: "94105", Country: "US"},
+		},
+		{
+			raw:  "1 Market St, San Francisco, California 94105",
+			want: AddressComponents{Address1: "1 Market St", City: "San Francisco", StateProvince: "CA", PostalCode: "94105", Country: "US"},
+		},
+		{raw: "123 Main Street, Seattle, WA", wantErr: true},
+		{raw: "Seattle, WA 98101", wantErr: true},
+		{raw: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.raw, tt.country)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAddress(%q) = %+v, want an error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAddress(%q): %v", tt.raw, err)
+			continue
+		}
+		address2 := ""
+		if got.Address2 != nil {
+			address2 = *got.Address2
+		}
+		got.Address2 = nil
+		if *got != tt.want || address2 != tt.address2 {
+			t.Errorf("ParseAddress(%q) = %+v line 2 %q, want %+v line 2 %q", tt.raw, *got, address2, tt.want, tt.address2)
+		}
+	}
+}
+
+func TestAddressNormalize(t *testing.T) {
+	tests := []struct {
+		address1, country, want string
+	}{
+		{"123 Main Street", "US", "123 MAIN ST"},
+		{"500 North Street", "US", "500 NORTH ST"},
+		{"500 North Main Street", "US", "500 N MAIN ST"},
+		{"500 Main Street North", "US", "500 MAIN ST N"},
+		{"500 South Avenue West", "US", "500 SOUTH AVE W"},
+		{"100 North Broadway", "US", "100 N BROADWAY"},
+		{"100 Broadway North", "US", "100 BROADWAY N"},
+		{"42 West", "US", "42 WEST"},
+		{"1600 Pennsylvania Avenue NW Suite 200", "US", "1600 PENNSYLVANIA AVE NW STE 200"},
+		{"100 Queen St. West", "ca", "100 QUEEN ST WEST"},
+		{"10 Downing Street", "gb", "10 Downing Street"},
+	}
+	for _, tt := range tests {
+		a := Address{Address1: tt.address1, City: "x", Country: tt.country}
+		a.Normalize()
+		if a.Address1 != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.address1, a.Address1, tt.want)
+		}
+	}
+
+	a := Address{Address1: "1 Main St", City: "Seattle", StateProvince: "Washington", PostalCode: "981011234", Country: "us"}
+	a.Normalize()
+	if a.City != "SEATTLE" || a.StateProvince != "WA" || a.PostalCode != "98101-1234" || a.Country != "US" {
+		t.Errorf("Normalize = %+v", a)
+	}
+	if err := a.Validate(); err != nil {
+		t.Errorf("Validate after Normalize: %v", err)
+	}
+}
+
+func TestAddressValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		wantErr bool
+	}{
+		{"US", Address{Country: "US", StateProvince: "WA", PostalCode: "98101"}, false},
+		{"Canada", Address{Country: "CA", StateProvince: "ON", PostalCode: "M5H 2N2"}, false},
+		{"other country", Address{Country: "GB", PostalCode: "SW1A 2AA"}, false},
+		{"unknown country", Address{Country: "XX"}, true},
+		{"bad state", Address{Country: "US", StateProvince: "ZZ", PostalCode: "98101"}, true},
+		{"bad ZIP", Address{Country: "US", StateProvince: "WA", PostalCode: "9810"}, true},
+		{"bad postal code", Address{Country: "CA", StateProvince: "ON", PostalCode: "12345"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.address.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
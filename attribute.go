@@ -0,0 +1,226 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// AttributeValueType is the type of value a taxonomy term expects on its attributes
+type AttributeValueType string
+
+// AttributeValueType values
+const (
+	AttributeValueString  AttributeValueType = "string"
+	AttributeValueBoolean AttributeValueType = "boolean"
+	AttributeValueNumber  AttributeValueType = "number"
+	AttributeValueDate    AttributeValueType = "date"
+)
+
+// AttributeValueTypes declares the value type of each taxonomy term, keyed by
+// term ID. Terms without an entry hold plain strings.
+type AttributeValueTypes map[string]AttributeValueType
+
+// linkEntityTables maps each link entity to the index of its Dataset slice
+var linkEntityTables = func() map[LinkEntityEnum]int {
+	tables := make(map[LinkEntityEnum]int)
+	t := reflect.TypeOf(Dataset{})
+	for i := 0; i < t.NumField(); i++ {
+		if elem := t.Field(i).Type.Elem(); elem != reflect.TypeOf(Metadata{}) && elem != reflect.TypeOf(MetaTableDescription{}) && elem != reflect.TypeOf(Attribute{}) {
+			tables[LinkEntityEnum(resourceTypeOf(elem))] = i
+		}
+	}
+	return tables
+}()
+
+// ValidateLinkEntity ensures s names, in any case, an HSDS table an Attribute
+// can link to
+func ValidateLinkEntity(s string) error {
+	_, err := parseLinkEntity(s)
+	return err
+}
+
+// parseLinkEntity returns the link entity s names in any case, or an error
+// suggesting the table s most likely means
+func parseLinkEntity(s string) (LinkEntityEnum, error) {
+	linkEntity := LinkEntityEnum(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := linkEntityTables[linkEntity]; ok {
+		return linkEntity, nil
+	}
+	if suggestion := suggestLinkEntity(s); suggestion != "" {
+		return "", fmt.Errorf("invalid link entity %q: did you mean %q?", s, suggestion)
+	}
+	return "", fmt.Errorf("invalid link entity %q: must be one of the HSDS tables", s)
+}
+
+// suggestLinkEntity guesses the link entity meant by s, written in camel case,
+// with spaces or hyphens, in the plural or with a typo, or returns ""
+func suggestLinkEntity(s string) LinkEntityEnum {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(s) {
+		switch {
+		case r == ' ' || r == '-':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	name := strings.ReplaceAll(b.String(), "__", "_")
+
+	candidates := []string{name}
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		candidates = append(candidates, strings.TrimSuffix(name, "ies")+"y")
+	case strings.HasSuffix(name, "es"):
+		candidates = append(candidates, strings.TrimSuffix(name, "es"), strings.TrimSuffix(name, "s"))
+	case strings.HasSuffix(name, "s"):
+		candidates = append(candidates, strings.TrimSuffix(name, "s"))
+	}
+	for _, c := range candidates {
+		if _, ok := linkEntityTables[LinkEntityEnum(c)]; ok {
+			return LinkEntityEnum(c)
+		}
+	}
+
+	var best LinkEntityEnum
+	bestScore := 0.85
+	for linkEntity := range linkEntityTables {
+		if score := jaroWinkler(name, string(linkEntity)); score > bestScore || (score == bestScore && linkEntity < best) {
+			best, bestScore = linkEntity, score
+		}
+	}
+	return best
+}
+
+// LinkEntityOf returns the link entity for an HSDS record or pointer to one
+func LinkEntityOf(entity any) (LinkEntityEnum, error) {
+	v := reflect.Indirect(reflect.ValueOf(entity))
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("%T is not an HSDS record", entity)
+	}
+	linkEntity := LinkEntityEnum(resourceTypeOf(v.Type()))
+	if _, ok := linkEntityTables[linkEntity]; !ok {
+		return "", fmt.Errorf("%T cannot be linked from an attribute", entity)
+	}
+	return linkEntity, nil
+}
+
+// AttributesFor returns the attributes in ds linked to entity, which may be
+// any HSDS record or pointer to one
+func AttributesFor(ds *Dataset, entity any) []Attribute {
+	linkEntity, err := LinkEntityOf(entity)
+	if err != nil {
+		return nil
+	}
+	id := reflect.Indirect(reflect.ValueOf(entity)).FieldByName("ID").String()
+
+	var result []Attribute
+	for _, attr := range ds.Attributes {
+		if attr.LinkID == id && strings.EqualFold(attr.LinkEntity, string(linkEntity)) {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// Entity returns the typed link entity of the attribute, in lower case
+func (a *Attribute) Entity() (LinkEntityEnum, error) {
+	return parseLinkEntity(a.LinkEntity)
+}
+
+// Target returns a pointer to the record in ds the attribute links to,
+// e.g. *Service when LinkEntity is "service"
+func (a *Attribute) Target(ds *Dataset) (any, error) {
+	linkEntity, err := a.Entity()
+	if err != nil {
+		return nil, err
+	}
+
+	table := reflect.ValueOf(ds).Elem().Field(linkEntityTables[linkEntity])
+	for i := 0; i < table.Len(); i++ {
+		record := table.Index(i)
+		if record.FieldByName("ID").String() == a.LinkID {
+			return record.Addr().Interface(), nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s linked from attribute %s not found", linkEntity, a.LinkID, a.ID)
+}
+
+// BoolValue parses the attribute value as a boolean ("true", "yes", "1", ...)
+func (a *Attribute) BoolValue() (bool, error) {
+	if a.Value == nil {
+		return false, fmt.Errorf("attribute %s has no value", a.ID)
+	}
+	switch strings.ToLower(strings.TrimSpace(*a.Value)) {
+	case "true", "t", "yes", "y", "1":
+		return true, nil
+	case "false", "f", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("attribute %s value %q is not a boolean", a.ID, *a.Value)
+}
+
+// NumberValue parses the attribute value as a number
+func (a *Attribute) NumberValue() (float64, error) {
+	if a.Value == nil {
+		return 0, fmt.Errorf("attribute %s has no value", a.ID)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(*a.Value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("attribute %s value %q is not a number", a.ID, *a.Value)
+	}
+	return n, nil
+}
+
+// DateValue parses the attribute value with any of the supported TimeFormats
+func (a *Attribute) DateValue() (time.Time, error) {
+	if a.Value == nil {
+		return time.Time{}, fmt.Errorf("attribute %s has no value", a.ID)
+	}
+	t, err := ParseTime(strings.TrimSpace(*a.Value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("attribute %s value %q is not a date", a.ID, *a.Value)
+	}
+	return t, nil
+}
+
+// TypedValue returns the attribute value as bool, float64, time.Time or
+// string according to the value type declared for its taxonomy term
+func (a *Attribute) TypedValue(types AttributeValueTypes) (any, error) {
+	switch types[a.TaxonomyTermID] {
+	case AttributeValueBoolean:
+		return a.BoolValue()
+	case AttributeValueNumber:
+		return a.NumberValue()
+	case AttributeValueDate:
+		return a.DateValue()
+	case AttributeValueString, "":
+		if a.Value == nil {
+			return nil, nil
+		}
+		return *a.Value, nil
+	default:
+		return nil, fmt.Errorf("unknown value type %q for taxonomy term %s", types[a.TaxonomyTermID], a.TaxonomyTermID)
+	}
+}
+
+// Validate checks the link entity and that the value parses as the type
+// declared for its taxonomy term
+func (a *Attribute) Validate(types AttributeValueTypes) error {
+	if err := ValidateLinkEntity(a.LinkEntity); err != nil {
+		return err
+	}
+	if a.Value == nil {
+		return nil
+	}
+	_, err := a.TypedValue(types)
+	return err
+}
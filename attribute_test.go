@@ -0,0 +1,94 @@
+package hsds_types
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateLinkEntity(t *testing.T) {
+	tests := []struct {
+		linkEntity string
+		wantErr    string
+	}{
+		{"service", ""},
+		{"Service", ""},
+		{"SERVICE_AT_LOCATION", ""},
+		{"services", `did you mean "service"`},
+		{"addresses", `did you mean "address"`},
+		{"accessibilities", `did you mean "accessibility"`},
+		{"ServiceAtLocation", `did you mean "service_at_location"`},
+		{"taxonomy term", `did you mean "taxonomy_term"`},
+		{"cost-options", `did you mean "cost_option"`},
+		{"sevrice", `did you mean "service"`},
+		{"attribute", "must be one of the HSDS tables"},
+		{"widget", "must be one of the HSDS tables"},
+	}
+	for _, tt := range tests {
+		err := ValidateLinkEntity(tt.linkEntity)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateLinkEntity(%q): %v", tt.linkEntity, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateLinkEntity(%q) = %v, want %q", tt.linkEntity, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAttributeTarget(t *testing.T) {
+	ds := &Dataset{
+		Services:   []Service{{ID: "s1", Name: "Pantry"}},
+		Attributes: []Attribute{{ID: "a1", LinkEntity: "Service", LinkID: "s1"}, {ID: "a2", LinkEntity: "location", LinkID: "s1"}},
+	}
+	a := ds.Attributes[0]
+	if entity, err := a.Entity(); err != nil || entity != LinkEntityService {
+		t.Errorf("Entity = %q, %v, want service", entity, err)
+	}
+	target, err := a.Target(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc, ok := target.(*Service); !ok || svc.Name != "Pantry" {
+		t.Errorf("Target = %#v", target)
+	}
+	if _, err := ds.Attributes[1].Target(ds); err == nil {
+		t.Error("Target found a location that does not exist")
+	}
+	if got := AttributesFor(ds, ds.Services[0]); len(got) != 1 || got[0].ID != "a1" {
+		t.Errorf("AttributesFor = %+v, want a1", got)
+	}
+}
+
+func TestAttributeTypedValue(t *testing.T) {
+	types := AttributeValueTypes{"open": AttributeValueBoolean, "capacity": AttributeValueNumber, "since": AttributeValueDate, "odd": "color"}
+	tests := []struct {
+		term, value string
+		want        any
+		wantErr     bool
+	}{
+		{"open", "Yes", true, false},
+		{"open", "maybe", nil, true},
+		{"capacity", " 12.5 ", 12.5, false},
+		{"capacity", "lots", nil, true},
+		{"since", "2024-03-03", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), false},
+		{"label", "anything", "anything", false},
+		{"odd", "red", nil, true},
+	}
+	for _, tt := range tests {
+		a := Attribute{ID: "a1", TaxonomyTermID: tt.term, LinkEntity: "service", Value: strPtr(tt.value)}
+		got, err := a.TypedValue(types)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s %q: error = %v, want error %v", tt.term, tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s %q = %#v, want %#v", tt.term, tt.value, got, tt.want)
+		}
+		if err := a.Validate(types); (err != nil) != tt.wantErr {
+			t.Errorf("%s %q: Validate = %v", tt.term, tt.value, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldChange is a single field that differs between two versions of a record
+type FieldChange struct {
+	// Field is the JSON name of the field, e.g. "alternate_name"
+	Field string `json:"field"`
+	// Previous and Replacement are the dereferenced values, nil when unset
+	Previous    any `json:"previous"`
+	Replacement any `json:"replacement"`
+}
+
+// Diff compares two versions of the same HSDS record type field by field and
+// returns the fields that changed, keyed by JSON name. Pointers are compared
+// by value, times by instant, and the ID and timestamps are ignored. Either
+// side may be nil to describe a creation or deletion; records of different
+// types yield no changes.
+func Diff(old, new any) []FieldChange {
+	vo, vn := recordValue(old), recordValue(new)
+	if !vo.IsValid() && !vn.IsValid() {
+		return nil
+	}
+
+	switch {
+	case !vo.IsValid():
+		vo = reflect.Zero(vn.Type())
+	case !vn.IsValid():
+		vn = reflect.Zero(vo.Type())
+	case vo.Type() != vn.Type():
+		return nil
+	}
+
+	var changes []FieldChange
+	for _, field := range dataFields(vo.Type()) {
+		fo, fn := vo.Field(field.index), vn.Field(field.index)
+		if fieldValuesEqual(fo, fn) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field:       field.name,
+			Previous:    derefValue(fo),
+			Replacement: derefValue(fn),
+		})
+	}
+	return changes
+}
+
+// TrackChanges diffs two versions of a record and returns one Metadata row per
+// changed field, attributed to actor and grouped under callID. A nil old
+// records a create and a nil new records a delete.
+func TrackChanges(old, new any, actor, callID string) ([]*Metadata, error) {
+	return defaultFactory.TrackChanges(old, new, actor, callID)
+}
+
+// TrackChanges is TrackChanges with Metadata rows created by the factory
+func (f *Factory) TrackChanges(old, new any, actor, callID string) ([]*Metadata, error) {
+	vo, vn := recordValue(old), recordValue(new)
+
+	var subject reflect.Value
+	action := ActionTypeUpdate
+	switch {
+	case !vo.IsValid() && !vn.IsValid():
+		return nil, fmt.Errorf("at least one version of the record is required")
+	case !vo.IsValid():
+		subject, action = vn, ActionTypeCreate
+	case !vn.IsValid():
+		subject, action = vo, ActionTypeDelete
+	default:
+		if vo.Type() != vn.Type() {
+			return nil, fmt.Errorf("cannot compare %s with %s", vo.Type(), vn.Type())
+		}
+		if oldID, newID := vo.FieldByName("ID").String(), vn.FieldByName("ID").String(); oldID != newID {
+			return nil, fmt.Errorf("cannot compare different records %s and %s", oldID, newID)
+		}
+		subject = vn
+	}
+
+	idField := subject.FieldByName("ID")
+	if !idField.IsValid() || idField.Kind() != reflect.String {
+		return nil, fmt.Errorf("%s has no ID field", subject.Type())
+	}
+
+	var history []*Metadata
+	for _, change := range Diff(old, new) {
+		meta, err := f.NewMetadata(MetadataOptions{
+			ResourceID:       idField.String(),
+			CallID:           callID,
+			ResourceType:     MetadataResourceTypeEnum(resourceTypeOf(subject.Type())),
+			LastActionType:   action,
+			FieldName:        change.Field,
+			PreviousValue:    formatFieldValue(reflect.ValueOf(change.Previous)),
+			ReplacementValue: formatFieldValue(reflect.ValueOf(change.Replacement)),
+			UpdatedBy:        actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, meta)
+	}
+	return history, nil
+}
+
+// recordValue dereferences v to its struct value; nil or non-struct input
+// returns the zero Value
+func recordValue(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+// fieldValuesEqual compares two field values, treating nil pointers and
+// invalid values as unset and comparing times by instant
+func fieldValuesEqual(a, b reflect.Value) bool {
+	a, b = reflect.ValueOf(derefValue(a)), reflect.ValueOf(derefValue(b))
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if ta, ok := asTime(a.Interface()); ok {
+		tb, ok := asTime(b.Interface())
+		return ok && a.Type() == b.Type() && ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// derefValue follows pointers and returns the underlying value, or nil when unset
+func derefValue(v reflect.Value) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
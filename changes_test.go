@@ -0,0 +1,106 @@
+package hsds_types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openingHours is a record with nested struct and slice fields
+type openingHours struct {
+	ID    string                         `json:"id"`
+	Hours struct{ Opens, Closes string } `json:"hours"`
+	Days  []string                       `json:"days"`
+}
+
+func TestDiff(t *testing.T) {
+	seattle := time.FixedZone("PST", -8*60*60)
+	date := NewFlexDate(testTime)
+	base := Service{ID: testID(1), OrganizationID: testID(2), Name: "Pantry", Email: strPtr("a@example.org"), AssuredDate: date}
+
+	tests := []struct {
+		name string
+		old  any
+		new  any
+		want []string
+	}{
+		{"identical", base, base, nil},
+		{"equal pointers at different addresses", base, func() Service { s := base; s.Email = strPtr("a@example.org"); return s }(), nil},
+		{"pointer set to nil", base, func() Service { s := base; s.Email = nil; return s }(), []string{"email"}},
+		{"pointer value changed", base, func() Service { s := base; s.Email = strPtr("b@example.org"); return s }(), []string{"email"}},
+		{"pointer to a date changed", base, func() Service { s := base; s.AssuredDate = NewFlexDate(testTime.AddDate(0, 0, 1)); return s }(), []string{"assured_date"}},
+		{"ID and timestamps ignored", base, func() Service { s := base; s.ID, s.UpdatedAt = testID(9), FlexTime{testTime}; return s }(), nil},
+		{"several fields in field order", &base, func() *Service { s := base; s.Name, s.OrganizationID = "Food Pantry", testID(3); return &s }(), []string{"organization_id", "name"}},
+		{"creation", nil, base, []string{"organization_id", "name", "email", "assured_date"}},
+		{"different types", base, Organization{}, nil},
+		{"same instant in another zone", ServiceCapacity{Updated: FlexTime{testTime}}, ServiceCapacity{Updated: FlexTime{testTime.In(seattle)}}, nil},
+		{"different instant", ServiceCapacity{Updated: FlexTime{testTime}}, ServiceCapacity{Updated: FlexTime{testTime.Add(time.Hour)}}, []string{"updated"}},
+		{"nested struct", openingHours{}, func() openingHours { var h openingHours; h.Hours.Opens = "09:00"; return h }(), []string{"hours"}},
+		{"nested slice", openingHours{Days: []string{"mo"}}, openingHours{Days: []string{"mo", "tu"}}, []string{"days"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range Diff(tt.old, tt.new) {
+			got = append(got, c.Field)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: changed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	changes := Diff(base, func() Service { s := base; s.Email = nil; return s }())
+	if changes[0].Previous != "a@example.org" || changes[0].Replacement != nil {
+		t.Errorf("email change = %+v, want dereferenced values", changes[0])
+	}
+}
+
+func TestTrackChanges(t *testing.T) {
+	f := newTestFactory()
+	old := &Service{ID: testID(1), OrganizationID: testID(2), Name: "Pantry", AssuredDate: NewFlexDate(testTime)}
+	updated := Clone(old)
+	updated.Name = "Food Pantry"
+	updated.AssuredDate = nil
+
+	history, err := f.TrackChanges(old, updated, "editor", "call-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("history = %d rows, want 2", len(history))
+	}
+	name, date := history[0], history[1]
+	if name.FieldName != "name" || name.PreviousValue != "Pantry" || name.ReplacementValue != "Food Pantry" || name.LastActionType != ActionTypeUpdate {
+		t.Errorf("name row = %+v", name)
+	}
+	if date.FieldName != "assured_date" || date.PreviousValue != "2024-03-03" || date.ReplacementValue != "" {
+		t.Errorf("assured_date row = %+v", date)
+	}
+	if name.ResourceType != ResourceTypeService || name.UpdatedBy != "editor" || name.CallID != "call-1" || !name.CreatedAt.Equal(testTime) {
+		t.Errorf("row attribution = %+v", name)
+	}
+
+	created, err := f.TrackChanges(nil, old, "importer", "")
+	if err != nil || len(created) != 3 || created[0].LastActionType != ActionTypeCreate {
+		t.Errorf("create rows = %d, %v", len(created), err)
+	}
+	deleted, err := f.TrackChanges(old, nil, "importer", "")
+	if err != nil || deleted[0].LastActionType != ActionTypeDelete {
+		t.Errorf("delete rows = %+v, %v", deleted, err)
+	}
+
+	errs := []struct {
+		name     string
+		old, new any
+		want     string
+	}{
+		{"nothing", nil, nil, "at least one version"},
+		{"different types", old, &Organization{ID: testID(1)}, "cannot compare"},
+		{"different records", old, &Service{ID: testID(5)}, "different records"},
+	}
+	for _, tt := range errs {
+		if _, err := f.TrackChanges(tt.old, tt.new, "", ""); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package hsds_types
+
+import (
+	"reflect"
+)
+
+// referenceFields are the JSON names of fields holding the ID of another record
+var referenceFields = []string{
+	"contact_id", "link_id", "location_id", "organization_id", "parent_id",
+	"parent_organization_id", "phone_id", "program_id", "resource_id",
+	"service_at_location_id", "service_id", "taxonomy_id", "taxonomy_term_id", "unit_id",
+}
+
+// Clone returns a deep copy of record; no pointer field of the copy aliases
+// the original, so either can be modified independently
+func Clone[T any](record *T) *T {
+	if record == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(record)).Interface().(*T)
+}
+
+// Clone returns a deep copy of every table in the dataset
+func (ds *Dataset) Clone() *Dataset {
+	return Clone(ds)
+}
+
+// copyTables returns a dataset whose tables are new slices holding copies of
+// the records; pointer fields are shared with ds
+func (ds *Dataset) copyTables() Dataset {
+	var c Dataset
+	src, dst := reflect.ValueOf(ds).Elem(), reflect.ValueOf(&c).Elem()
+	for t := 0; t < src.NumField(); t++ {
+		if table := src.Field(t); !table.IsNil() {
+			dst.Field(t).Set(reflect.AppendSlice(reflect.MakeSlice(table.Type(), 0, table.Len()), table))
+		}
+	}
+	return c
+}
+
+// EqualOptions contains optional settings for Equal
+type EqualOptions struct {
+	// IgnoreIDs skips the record's ID and every field referencing another
+	// record, to compare records re-imported under new IDs
+	IgnoreIDs bool
+	// IgnoreFields skips further fields by JSON name
+	IgnoreFields []string
+}
+
+// Equal reports whether two records of the same HSDS type hold the same
+// data. CreatedAt and UpdatedAt are always ignored, pointers are compared by
+// value and times by instant.
+func Equal(a, b any, opts *EqualOptions) bool {
+	if opts == nil {
+		opts = &EqualOptions{}
+	}
+	va, vb := recordValue(a), recordValue(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	if !opts.IgnoreIDs {
+		if ia, ib := va.FieldByName("ID"), vb.FieldByName("ID"); ia.IsValid() && ia.String() != ib.String() {
+			return false
+		}
+	}
+	for _, field := range dataFields(va.Type()) {
+		if contains(opts.IgnoreFields, field.name) || (opts.IgnoreIDs && contains(referenceFields, field.name)) {
+			continue
+		}
+		if !fieldValuesEqual(va.Field(field.index), vb.Field(field.index)) {
+			return false
+		}
+	}
+	return true
+}
+
+// deepCopy copies v, following pointers, slices and maps. Pointers and maps
+// reached more than once, such as a GORM Parent pointing back at its
+// Children, are copied once so cycles terminate and sharing is kept.
+func deepCopy(v reflect.Value) reflect.Value {
+	return copier{}.copy(v)
+}
+
+// copyKey identifies a pointer or map already copied
+type copyKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copier holds the copies made by one deepCopy
+type copier map[copyKey]reflect.Value
+
+func (cp copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := copyKey{v.Pointer(), v.Type()}
+		if c, ok := cp[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		cp[key] = c
+		c.Elem().Set(cp.copy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(cp.copy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cp.copy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := copyKey{v.Pointer(), v.Type()}
+		if c, ok := cp[key]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		cp[key] = c
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), cp.copy(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
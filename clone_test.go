@@ -0,0 +1,74 @@
+package hsds_types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	org := &Organization{ID: testID(1), Name: "Food Bank", Email: strPtr("info@example.org")}
+	c := Clone(org)
+	*c.Email = "changed@example.org"
+	c.Name = "Changed"
+	if *org.Email != "info@example.org" || org.Name != "Food Bank" {
+		t.Errorf("original changed through the clone: %+v", org)
+	}
+	if Clone[Organization](nil) != nil {
+		t.Error("Clone(nil) is not nil")
+	}
+
+	ds := &Dataset{Services: []Service{{ID: testID(2), Name: "Pantry"}}}
+	dc := ds.Clone()
+	dc.Services[0].Name = "Clinic"
+	if ds.Services[0].Name != "Pantry" {
+		t.Error("dataset changed through its clone")
+	}
+}
+
+func TestCloneCycle(t *testing.T) {
+	// GORM relation fields can point back at the record holding them
+	parent := &TaxonomyTerm{ID: testID(1), Name: "Food"}
+	parent.Children = []TaxonomyTerm{{ID: testID(2), ParentID: &parent.ID, Name: "Pantry", Parent: parent}}
+
+	c := Clone(parent)
+	if c == parent || len(c.Children) != 1 || c.Children[0].Parent != c {
+		t.Fatalf("clone = %+v, want its child to point back at the clone", c)
+	}
+	c.Children[0].Parent.Name = "Groceries"
+	if parent.Name != "Food" || c.Name != "Groceries" {
+		t.Errorf("names = %q and %q", parent.Name, c.Name)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	seattle := time.FixedZone("PST", -8*60*60)
+	base := ServiceCapacity{ID: testID(1), ServiceID: testID(2), UnitID: testID(3), Available: 4, Updated: FlexTime{testTime}}
+	tests := []struct {
+		name string
+		b    ServiceCapacity
+		opts *EqualOptions
+		want bool
+	}{
+		{"identical", base, nil, true},
+		{"timestamps ignored", func() ServiceCapacity { c := base; c.UpdatedAt = FlexTime{testTime}; return c }(), nil, true},
+		{"same instant in another zone", func() ServiceCapacity { c := base; c.Updated = FlexTime{testTime.In(seattle)}; return c }(), nil, true},
+		{"different value", func() ServiceCapacity { c := base; c.Available = 5; return c }(), nil, false},
+		{"different ID", func() ServiceCapacity { c := base; c.ID = testID(9); return c }(), nil, false},
+		{"IDs ignored", func() ServiceCapacity { c := base; c.ID, c.ServiceID = testID(9), testID(8); return c }(), &EqualOptions{IgnoreIDs: true}, true},
+		{"field ignored", func() ServiceCapacity { c := base; c.Available = 5; return c }(), &EqualOptions{IgnoreFields: []string{"available"}}, true},
+	}
+	for _, tt := range tests {
+		if got := Equal(base, tt.b, tt.opts); got != tt.want {
+			t.Errorf("%s: Equal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// pointers are compared by value
+	a, b := Organization{ID: testID(1), Email: strPtr("x@example.org")}, Organization{ID: testID(1), Email: strPtr("x@example.org")}
+	if !Equal(&a, b, nil) {
+		t.Error("equal pointer values compared by address")
+	}
+	if Equal(a, Service{ID: testID(1)}, nil) || !Equal(nil, nil, nil) {
+		t.Error("Equal across types or of nils")
+	}
+}
@@ -7,14 +7,22 @@ import (
 	"github.com/google/uuid"
 )
 
-// ValidateUUID ensures the string is a valid UUIDv4
-func ValidateUUID(u string) bool {
+// ValidateUUID ensures the string is a valid UUID of one of the given
+// versions, or a UUIDv4 when none are given
+func ValidateUUID(u string, versions ...int) bool {
 	id, err := uuid.Parse(u)
 	if err != nil {
 		return false
 	}
-	// Verify it's a v4 UUID
-	return id.Version() == 4
+	if len(versions) == 0 {
+		versions = []int{4}
+	}
+	for _, v := range versions {
+		if int(id.Version()) == v {
+			return true
+		}
+	}
+	return false
 }
 
 // newUUIDV4 generates a new UUIDv4 string
@@ -44,23 +52,27 @@ type OrganizationOptions struct {
 
 // NewOrganization creates a new Organization with required fields and optional fields via OrganizationOptions
 func NewOrganization(name, description string, opts *OrganizationOptions) (*Organization, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewOrganization(name, description, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewOrganization creates a new Organization using the factory's Clock and IDGenerator
+func (f *Factory) NewOrganization(name, description string, opts *OrganizationOptions) (*Organization, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeOrganization)
+	if err != nil {
+		return nil, err
 	}
 
 	org := &Organization{
-		CreatedAt:   now,
+		CreatedAt:   FlexTime{now},
 		ID:          id,
 		Name:        name,
 		Description: description,
 	}
 
 	if opts != nil {
-		if opts.ParentOrganizationID != nil && !ValidateUUID(*opts.ParentOrganizationID) {
-			return nil, fmt.Errorf("invalid parent organization ID format: must be UUIDv4")
+		if opts.ParentOrganizationID != nil && !f.validUUID(*opts.ParentOrganizationID) {
+			return nil, fmt.Errorf("invalid parent organization ID format: must be a UUID")
 		}
 		org.ParentOrganizationID = opts.ParentOrganizationID
 		org.AlternateName = opts.AlternateName
@@ -84,19 +96,23 @@ type OrganizationIdentifierOptions struct {
 
 // NewOrganizationIdentifier creates a new OrganizationIdentifier with required fields and optional fields
 func NewOrganizationIdentifier(organizationID, identifierType, identifier string, opts *OrganizationIdentifierOptions) (*OrganizationIdentifier, error) {
-	if !ValidateUUID(organizationID) {
-		return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
-	}
+	return defaultFactory.NewOrganizationIdentifier(organizationID, identifierType, identifier, opts)
+}
 
-	now := getICalTime()
-	id := newUUIDV4()
+// NewOrganizationIdentifier creates a new OrganizationIdentifier using the factory's Clock and IDGenerator
+func (f *Factory) NewOrganizationIdentifier(organizationID, identifierType, identifier string, opts *OrganizationIdentifierOptions) (*OrganizationIdentifier, error) {
+	if !f.validUUID(organizationID) {
+		return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
+	}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeOrganizationIdentifier)
+	if err != nil {
+		return nil, err
 	}
 
 	orgIdentifier := &OrganizationIdentifier{
-		CreatedAt:      now,
+		CreatedAt:      FlexTime{now},
 		ID:             id,
 		OrganizationID: organizationID,
 		IdentifierType: identifierType,
@@ -119,25 +135,29 @@ type URLOptions struct {
 
 // NewURL creates a new URL with required fields and optional fields
 func NewURL(url string, opts *URLOptions) (*URL, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewURL(url, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewURL creates a new URL using the factory's Clock and IDGenerator
+func (f *Factory) NewURL(url string, opts *URLOptions) (*URL, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeURL)
+	if err != nil {
+		return nil, err
 	}
 
 	urlObj := &URL{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 		URL:       url,
 	}
 
 	if opts != nil {
-		if opts.OrganizationID != nil && !ValidateUUID(*opts.OrganizationID) {
-			return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
+		if opts.OrganizationID != nil && !f.validUUID(*opts.OrganizationID) {
+			return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
 		}
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
 		urlObj.OrganizationID = opts.OrganizationID
 		urlObj.ServiceID = opts.ServiceID
@@ -155,24 +175,28 @@ type FundingOptions struct {
 }
 
 func NewFunding(opts *FundingOptions) (*Funding, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewFunding(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewFunding creates a new Funding using the factory's Clock and IDGenerator
+func (f *Factory) NewFunding(opts *FundingOptions) (*Funding, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeFunding)
+	if err != nil {
+		return nil, err
 	}
 
 	funding := &Funding{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.OrganizationID != nil && !ValidateUUID(*opts.OrganizationID) {
-			return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
+		if opts.OrganizationID != nil && !f.validUUID(*opts.OrganizationID) {
+			return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
 		}
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
 		funding.OrganizationID = opts.OrganizationID
 		funding.ServiceID = opts.ServiceID
@@ -190,15 +214,19 @@ type UnitOptions struct {
 }
 
 func NewUnit(name string, opts *UnitOptions) (*Unit, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewUnit(name, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewUnit creates a new Unit using the factory's Clock and IDGenerator
+func (f *Factory) NewUnit(name string, opts *UnitOptions) (*Unit, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeUnit)
+	if err != nil {
+		return nil, err
 	}
 
 	unit := &Unit{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 		Name:      name,
 	}
@@ -218,19 +246,23 @@ type ProgramOptions struct {
 }
 
 func NewProgram(organizationID, name, description string, opts *ProgramOptions) (*Program, error) {
-	if !ValidateUUID(organizationID) {
-		return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
-	}
+	return defaultFactory.NewProgram(organizationID, name, description, opts)
+}
 
-	now := getICalTime()
-	id := newUUIDV4()
+// NewProgram creates a new Program using the factory's Clock and IDGenerator
+func (f *Factory) NewProgram(organizationID, name, description string, opts *ProgramOptions) (*Program, error) {
+	if !f.validUUID(organizationID) {
+		return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
+	}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeProgram)
+	if err != nil {
+		return nil, err
 	}
 
 	program := &Program{
-		CreatedAt:      now,
+		CreatedAt:      FlexTime{now},
 		ID:             id,
 		OrganizationID: organizationID,
 		Name:           name,
@@ -260,26 +292,30 @@ type ServiceOptions struct {
 	EligibilityDescription *string
 	MinimumAge             *float64
 	MaximumAge             *float64
-	AssuredDate            *time.Time
+	AssuredDate            *FlexDate
 	AssurerEmail           *string
 	Licenses               *string
 	Alert                  *string
 }
 
 func NewService(organizationID, name string, status ServiceStatusEnum, opts *ServiceOptions) (*Service, error) {
-	if !ValidateUUID(organizationID) {
-		return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
-	}
+	return defaultFactory.NewService(organizationID, name, status, opts)
+}
 
-	now := getICalTime()
-	id := newUUIDV4()
+// NewService creates a new Service using the factory's Clock and IDGenerator
+func (f *Factory) NewService(organizationID, name string, status ServiceStatusEnum, opts *ServiceOptions) (*Service, error) {
+	if !f.validUUID(organizationID) {
+		return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
+	}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeService)
+	if err != nil {
+		return nil, err
 	}
 
 	service := &Service{
-		CreatedAt:      now,
+		CreatedAt:      FlexTime{now},
 		ID:             id,
 		OrganizationID: organizationID,
 		Name:           name,
@@ -287,8 +323,8 @@ func NewService(organizationID, name string, status ServiceStatusEnum, opts *Ser
 	}
 
 	if opts != nil {
-		if opts.ProgramID != nil && !ValidateUUID(*opts.ProgramID) {
-			return nil, fmt.Errorf("invalid program ID format: must be UUIDv4")
+		if opts.ProgramID != nil && !f.validUUID(*opts.ProgramID) {
+			return nil, fmt.Errorf("invalid program ID format: must be a UUID")
 		}
 		service.ProgramID = opts.ProgramID
 		service.AlternateName = opts.AlternateName
@@ -325,24 +361,28 @@ type ServiceAreaOptions struct {
 }
 
 func NewServiceArea(opts *ServiceAreaOptions) (*ServiceArea, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewServiceArea(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewServiceArea creates a new ServiceArea using the factory's Clock and IDGenerator
+func (f *Factory) NewServiceArea(opts *ServiceAreaOptions) (*ServiceArea, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeServiceArea)
+	if err != nil {
+		return nil, err
 	}
 
 	serviceArea := &ServiceArea{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
-		if opts.ServiceAtLocationID != nil && !ValidateUUID(*opts.ServiceAtLocationID) {
-			return nil, fmt.Errorf("invalid service at location ID format: must be UUIDv4")
+		if opts.ServiceAtLocationID != nil && !f.validUUID(*opts.ServiceAtLocationID) {
+			return nil, fmt.Errorf("invalid service at location ID format: must be a UUID")
 		}
 		serviceArea.ServiceID = opts.ServiceID
 		serviceArea.ServiceAtLocationID = opts.ServiceAtLocationID
@@ -362,22 +402,26 @@ type ServiceAtLocationOptions struct {
 }
 
 func NewServiceAtLocation(serviceID, locationID string, opts *ServiceAtLocationOptions) (*ServiceAtLocation, error) {
-	if !ValidateUUID(serviceID) {
-		return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+	return defaultFactory.NewServiceAtLocation(serviceID, locationID, opts)
+}
+
+// NewServiceAtLocation creates a new ServiceAtLocation using the factory's Clock and IDGenerator
+func (f *Factory) NewServiceAtLocation(serviceID, locationID string, opts *ServiceAtLocationOptions) (*ServiceAtLocation, error) {
+	if !f.validUUID(serviceID) {
+		return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 	}
-	if !ValidateUUID(locationID) {
-		return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+	if !f.validUUID(locationID) {
+		return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 	}
 
-	now := getICalTime()
-	id := newUUIDV4()
-
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeServiceAtLocation)
+	if err != nil {
+		return nil, err
 	}
 
 	serviceAtLocation := &ServiceAtLocation{
-		CreatedAt:  now,
+		CreatedAt:  FlexTime{now},
 		ID:         id,
 		ServiceID:  serviceID,
 		LocationID: locationID,
@@ -405,22 +449,26 @@ type LocationOptions struct {
 }
 
 func NewLocation(locationType LocationLocationTypeEnum, opts *LocationOptions) (*Location, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewLocation(locationType, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewLocation creates a new Location using the factory's Clock and IDGenerator
+func (f *Factory) NewLocation(locationType LocationLocationTypeEnum, opts *LocationOptions) (*Location, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeLocation)
+	if err != nil {
+		return nil, err
 	}
 
 	location := &Location{
-		CreatedAt:    now,
+		CreatedAt:    FlexTime{now},
 		ID:           id,
 		LocationType: locationType,
 	}
 
 	if opts != nil {
-		if opts.OrganizationID != nil && !ValidateUUID(*opts.OrganizationID) {
-			return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
+		if opts.OrganizationID != nil && !f.validUUID(*opts.OrganizationID) {
+			return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
 		}
 		location.OrganizationID = opts.OrganizationID
 		location.URL = opts.URL
@@ -450,19 +498,27 @@ func NewAddress(
 	addressType LocationLocationTypeEnum,
 	opts *AddressOptions,
 ) (*Address, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewAddress(address1, city, stateProvince, postalCode, country, addressType, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewAddress creates a new Address using the factory's Clock and IDGenerator
+func (f *Factory) NewAddress(
+	address1, city, stateProvince, postalCode, country string,
+	addressType LocationLocationTypeEnum,
+	opts *AddressOptions,
+) (*Address, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeAddress)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(country) != 2 {
-		return nil, fmt.Errorf("country must be a 2-letter code")
+	if err := validCountry(country); err != nil {
+		return nil, err
 	}
 
 	address := &Address{
-		CreatedAt:     now,
+		CreatedAt:     FlexTime{now},
 		ID:            id,
 		Address1:      address1,
 		City:          city,
@@ -473,8 +529,8 @@ func NewAddress(
 	}
 
 	if opts != nil {
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
 		address.LocationID = opts.LocationID
 		address.Attention = opts.Attention
@@ -493,21 +549,25 @@ type RequiredDocumentOptions struct {
 }
 
 func NewRequiredDocument(opts *RequiredDocumentOptions) (*RequiredDocument, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewRequiredDocument(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewRequiredDocument creates a new RequiredDocument using the factory's Clock and IDGenerator
+func (f *Factory) NewRequiredDocument(opts *RequiredDocumentOptions) (*RequiredDocument, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeRequiredDocument)
+	if err != nil {
+		return nil, err
 	}
 
 	requiredDocument := &RequiredDocument{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
 		requiredDocument.ServiceID = opts.ServiceID
 		requiredDocument.Document = opts.Document
@@ -528,27 +588,31 @@ type LanguageOptions struct {
 }
 
 func NewLanguage(opts *LanguageOptions) (*Language, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewLanguage(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewLanguage creates a new Language using the factory's Clock and IDGenerator
+func (f *Factory) NewLanguage(opts *LanguageOptions) (*Language, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeLanguage)
+	if err != nil {
+		return nil, err
 	}
 
 	language := &Language{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
-		if opts.PhoneID != nil && !ValidateUUID(*opts.PhoneID) {
-			return nil, fmt.Errorf("invalid phone ID format: must be UUIDv4")
+		if opts.PhoneID != nil && !f.validUUID(*opts.PhoneID) {
+			return nil, fmt.Errorf("invalid phone ID format: must be a UUID")
 		}
 		language.ServiceID = opts.ServiceID
 		language.LocationID = opts.LocationID
@@ -570,21 +634,25 @@ type AccessibilityOptions struct {
 }
 
 func NewAccessibility(opts *AccessibilityOptions) (*Accessibility, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewAccessibility(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewAccessibility creates a new Accessibility using the factory's Clock and IDGenerator
+func (f *Factory) NewAccessibility(opts *AccessibilityOptions) (*Accessibility, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeAccessibility)
+	if err != nil {
+		return nil, err
 	}
 
 	accessibility := &Accessibility{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
 		accessibility.LocationID = opts.LocationID
 		accessibility.Description = opts.Description
@@ -603,19 +671,26 @@ type AttributeOptions struct {
 }
 
 func NewAttribute(taxonomyTermID, linkID, linkEntity string, opts *AttributeOptions) (*Attribute, error) {
-	if !ValidateUUID(taxonomyTermID) {
-		return nil, fmt.Errorf("invalid taxonomy term ID format: must be UUIDv4")
-	}
+	return defaultFactory.NewAttribute(taxonomyTermID, linkID, linkEntity, opts)
+}
 
-	now := getICalTime()
-	id := newUUIDV4()
+// NewAttribute creates a new Attribute using the factory's Clock and IDGenerator
+func (f *Factory) NewAttribute(taxonomyTermID, linkID, linkEntity string, opts *AttributeOptions) (*Attribute, error) {
+	if !f.validUUID(taxonomyTermID) {
+		return nil, fmt.Errorf("invalid taxonomy term ID format: must be a UUID")
+	}
+	if err := ValidateLinkEntity(linkEntity); err != nil {
+		return nil, err
+	}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeAttribute)
+	if err != nil {
+		return nil, err
 	}
 
 	attribute := &Attribute{
-		CreatedAt:      now,
+		CreatedAt:      FlexTime{now},
 		ID:             id,
 		TaxonomyTermID: taxonomyTermID,
 		LinkID:         linkID,
@@ -638,15 +713,19 @@ type TaxonomyOptions struct {
 }
 
 func NewTaxonomy(name, description string, opts *TaxonomyOptions) (*Taxonomy, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewTaxonomy(name, description, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewTaxonomy creates a new Taxonomy using the factory's Clock and IDGenerator
+func (f *Factory) NewTaxonomy(name, description string, opts *TaxonomyOptions) (*Taxonomy, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeTaxonomy)
+	if err != nil {
+		return nil, err
 	}
 
 	taxonomy := &Taxonomy{
-		CreatedAt:   now,
+		CreatedAt:   FlexTime{now},
 		ID:          id,
 		Name:        name,
 		Description: description,
@@ -671,26 +750,30 @@ type TaxonomyTermOptions struct {
 }
 
 func NewTaxonomyTerm(name, description string, opts *TaxonomyTermOptions) (*TaxonomyTerm, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewTaxonomyTerm(name, description, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewTaxonomyTerm creates a new TaxonomyTerm using the factory's Clock and IDGenerator
+func (f *Factory) NewTaxonomyTerm(name, description string, opts *TaxonomyTermOptions) (*TaxonomyTerm, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeTaxonomyTerm)
+	if err != nil {
+		return nil, err
 	}
 
 	taxonomyTerm := &TaxonomyTerm{
-		CreatedAt:   now,
+		CreatedAt:   FlexTime{now},
 		ID:          id,
 		Name:        name,
 		Description: description,
 	}
 
 	if opts != nil {
-		if opts.TaxonomyID != nil && !ValidateUUID(*opts.TaxonomyID) {
-			return nil, fmt.Errorf("invalid taxonomy ID format: must be UUIDv4")
+		if opts.TaxonomyID != nil && !f.validUUID(*opts.TaxonomyID) {
+			return nil, fmt.Errorf("invalid taxonomy ID format: must be a UUID")
 		}
-		if opts.ParentID != nil && !ValidateUUID(*opts.ParentID) {
-			return nil, fmt.Errorf("invalid parent ID format: must be UUIDv4")
+		if opts.ParentID != nil && !f.validUUID(*opts.ParentID) {
+			return nil, fmt.Errorf("invalid parent ID format: must be a UUID")
 		}
 		taxonomyTerm.TaxonomyID = opts.TaxonomyID
 		taxonomyTerm.ParentID = opts.ParentID
@@ -716,30 +799,34 @@ type ContactOptions struct {
 }
 
 func NewContact(opts *ContactOptions) (*Contact, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewContact(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewContact creates a new Contact using the factory's Clock and IDGenerator
+func (f *Factory) NewContact(opts *ContactOptions) (*Contact, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeContact)
+	if err != nil {
+		return nil, err
 	}
 
 	contact := &Contact{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.OrganizationID != nil && !ValidateUUID(*opts.OrganizationID) {
-			return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
+		if opts.OrganizationID != nil && !f.validUUID(*opts.OrganizationID) {
+			return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
 		}
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
-		if opts.ServiceAtLocationID != nil && !ValidateUUID(*opts.ServiceAtLocationID) {
-			return nil, fmt.Errorf("invalid service at location ID format: must be UUIDv4")
+		if opts.ServiceAtLocationID != nil && !f.validUUID(*opts.ServiceAtLocationID) {
+			return nil, fmt.Errorf("invalid service at location ID format: must be a UUID")
 		}
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
 		contact.OrganizationID = opts.OrganizationID
 		contact.ServiceID = opts.ServiceID
@@ -761,40 +848,44 @@ type PhoneOptions struct {
 	OrganizationID      *string
 	ContactID           *string
 	ServiceAtLocationID *string
-	Extension           *float64
+	Extension           *PhoneExtension
 	Type                *string
 	Description         *string
 }
 
 func NewPhone(number string, opts *PhoneOptions) (*Phone, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewPhone(number, opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewPhone creates a new Phone using the factory's Clock and IDGenerator
+func (f *Factory) NewPhone(number string, opts *PhoneOptions) (*Phone, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypePhone)
+	if err != nil {
+		return nil, err
 	}
 
 	phone := &Phone{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 		Number:    number,
 	}
 
 	if opts != nil {
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
-		if opts.OrganizationID != nil && !ValidateUUID(*opts.OrganizationID) {
-			return nil, fmt.Errorf("invalid organization ID format: must be UUIDv4")
+		if opts.OrganizationID != nil && !f.validUUID(*opts.OrganizationID) {
+			return nil, fmt.Errorf("invalid organization ID format: must be a UUID")
 		}
-		if opts.ContactID != nil && !ValidateUUID(*opts.ContactID) {
-			return nil, fmt.Errorf("invalid contact ID format: must be UUIDv4")
+		if opts.ContactID != nil && !f.validUUID(*opts.ContactID) {
+			return nil, fmt.Errorf("invalid contact ID format: must be a UUID")
 		}
-		if opts.ServiceAtLocationID != nil && !ValidateUUID(*opts.ServiceAtLocationID) {
-			return nil, fmt.Errorf("invalid service at location ID format: must be UUIDv4")
+		if opts.ServiceAtLocationID != nil && !f.validUUID(*opts.ServiceAtLocationID) {
+			return nil, fmt.Errorf("invalid service at location ID format: must be a UUID")
 		}
 		phone.LocationID = opts.LocationID
 		phone.ServiceID = opts.ServiceID
@@ -814,11 +905,11 @@ type ScheduleOptions struct {
 	ServiceID           *string
 	LocationID          *string
 	ServiceAtLocationID *string
-	ValidFrom           *time.Time
-	ValidTo             *time.Time
-	DTStart             *time.Time
+	ValidFrom           *FlexDate
+	ValidTo             *FlexDate
+	DTStart             *FlexDate
 	Timezone            *float64
-	Until               *time.Time
+	Until               *FlexDate
 	Count               *int
 	Wkst                *ScheduleWkstEnum
 	Freq                *ScheduleFreqEnum
@@ -828,35 +919,39 @@ type ScheduleOptions struct {
 	Bymonthday          *string
 	Byyearday           *string
 	Description         *string
-	OpensAt             *time.Time
-	ClosesAt            *time.Time
+	OpensAt             *FlexClock
+	ClosesAt            *FlexClock
 	ScheduleLink        *string
 	AttendingType       *string
 	Notes               *string
 }
 
 func NewSchedule(opts *ScheduleOptions) (*Schedule, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewSchedule(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewSchedule creates a new Schedule using the factory's Clock and IDGenerator
+func (f *Factory) NewSchedule(opts *ScheduleOptions) (*Schedule, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeSchedule)
+	if err != nil {
+		return nil, err
 	}
 
 	schedule := &Schedule{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
 	if opts != nil {
-		if opts.ServiceID != nil && !ValidateUUID(*opts.ServiceID) {
-			return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+		if opts.ServiceID != nil && !f.validUUID(*opts.ServiceID) {
+			return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 		}
-		if opts.LocationID != nil && !ValidateUUID(*opts.LocationID) {
-			return nil, fmt.Errorf("invalid location ID format: must be UUIDv4")
+		if opts.LocationID != nil && !f.validUUID(*opts.LocationID) {
+			return nil, fmt.Errorf("invalid location ID format: must be a UUID")
 		}
-		if opts.ServiceAtLocationID != nil && !ValidateUUID(*opts.ServiceAtLocationID) {
-			return nil, fmt.Errorf("invalid service at location ID format: must be UUIDv4")
+		if opts.ServiceAtLocationID != nil && !f.validUUID(*opts.ServiceAtLocationID) {
+			return nil, fmt.Errorf("invalid service at location ID format: must be a UUID")
 		}
 		schedule.ServiceID = opts.ServiceID
 		schedule.LocationID = opts.LocationID
@@ -892,22 +987,26 @@ type ServiceCapacityOptions struct {
 }
 
 func NewServiceCapacity(serviceID, unitID string, available float64, opts *ServiceCapacityOptions) (*ServiceCapacity, error) {
-	if !ValidateUUID(serviceID) {
-		return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
+	return defaultFactory.NewServiceCapacity(serviceID, unitID, available, opts)
+}
+
+// NewServiceCapacity creates a new ServiceCapacity using the factory's Clock and IDGenerator
+func (f *Factory) NewServiceCapacity(serviceID, unitID string, available float64, opts *ServiceCapacityOptions) (*ServiceCapacity, error) {
+	if !f.validUUID(serviceID) {
+		return nil, fmt.Errorf("invalid service ID format: must be a UUID")
 	}
-	if !ValidateUUID(unitID) {
-		return nil, fmt.Errorf("invalid unit ID format: must be UUIDv4")
+	if !f.validUUID(unitID) {
+		return nil, fmt.Errorf("invalid unit ID format: must be a UUID")
 	}
 
-	now := getICalTime()
-	id := newUUIDV4()
-
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeServiceCapacity)
+	if err != nil {
+		return nil, err
 	}
 
 	serviceCapacity := &ServiceCapacity{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 		ServiceID: serviceID,
 		UnitID:    unitID,
@@ -924,8 +1023,8 @@ func NewServiceCapacity(serviceID, unitID string, available float64, opts *Servi
 
 // CostOptionOptions contains optional fields for creating a CostOption
 type CostOptionOptions struct {
-	ValidFrom         *time.Time
-	ValidTo           *time.Time
+	ValidFrom         *FlexDate
+	ValidTo           *FlexDate
 	Option            *string
 	Currency          *string
 	Amount            *float64
@@ -933,19 +1032,23 @@ type CostOptionOptions struct {
 }
 
 func NewCostOption(serviceID string, opts *CostOptionOptions) (*CostOption, error) {
-	if !ValidateUUID(serviceID) {
-		return nil, fmt.Errorf("invalid service ID format: must be UUIDv4")
-	}
+	return defaultFactory.NewCostOption(serviceID, opts)
+}
 
-	now := getICalTime()
-	id := newUUIDV4()
+// NewCostOption creates a new CostOption using the factory's Clock and IDGenerator
+func (f *Factory) NewCostOption(serviceID string, opts *CostOptionOptions) (*CostOption, error) {
+	if !f.validUUID(serviceID) {
+		return nil, fmt.Errorf("invalid service ID format: must be a UUID")
+	}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+	now := f.now()
+	id, err := f.newID(ResourceTypeCostOption)
+	if err != nil {
+		return nil, err
 	}
 
 	costOption := &CostOption{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 		ServiceID: serviceID,
 	}
@@ -964,15 +1067,20 @@ func NewCostOption(serviceID string, opts *CostOptionOptions) (*CostOption, erro
 
 // MetadataOptions contains all required fields for creating a Metadata
 type MetadataOptions struct {
-	ResourceType     string
-	LastActionType   string
+	ResourceID       string
+	CallID           string
+	ResourceType     MetadataResourceTypeEnum
+	LastActionType   MetadataLastActionTypeEnum
 	FieldName        string
 	PreviousValue    string
 	ReplacementValue string
 	UpdatedBy        string
 }
 
-// NewMetadata creates a new Metadata record with all required fields
+// NewMetadata creates a new Metadata record with all required fields.
+//
+// Deprecated: use NewMetadataFromOptions, which takes typed resource and
+// action types.
 func NewMetadata(
 	resourceID string,
 	callId string,
@@ -983,25 +1091,56 @@ func NewMetadata(
 	replacementValue string,
 	updatedBy string,
 ) (*Metadata, error) {
-	now := getICalTime()
-	id := newUUIDV4()
-
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
-	}
-
-	metadata := &Metadata{
-		CreatedAt:        now,
-		ID:               id,
-		CallID:           callId,
+	return NewMetadataFromOptions(MetadataOptions{
 		ResourceID:       resourceID,
-		ResourceType:     resourceType,
-		LastActionDate:   now,
-		LastActionType:   lastActionType,
+		CallID:           callId,
+		ResourceType:     MetadataResourceTypeEnum(resourceType),
+		LastActionType:   MetadataLastActionTypeEnum(lastActionType),
 		FieldName:        fieldName,
 		PreviousValue:    previousValue,
 		ReplacementValue: replacementValue,
 		UpdatedBy:        updatedBy,
+	})
+}
+
+// NewMetadataFromOptions creates a new Metadata record with all required fields
+func NewMetadataFromOptions(opts MetadataOptions) (*Metadata, error) {
+	return defaultFactory.NewMetadata(opts)
+}
+
+// NewMetadata creates a new Metadata using the factory's Clock and IDGenerator
+func (f *Factory) NewMetadata(opts MetadataOptions) (*Metadata, error) {
+	if !f.validUUID(opts.ResourceID) {
+		return nil, fmt.Errorf("invalid resource ID format: must be a UUID")
+	}
+	if err := ValidateResourceType(string(opts.ResourceType)); err != nil {
+		return nil, err
+	}
+	if err := ValidateActionType(string(opts.LastActionType)); err != nil {
+		return nil, err
+	}
+	if opts.FieldName == "" {
+		return nil, fmt.Errorf("field name is required")
+	}
+
+	now := f.now()
+	id, err := f.newID(ResourceTypeMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{
+		CreatedAt:        FlexTime{now},
+		ID:               id,
+		CallID:           opts.CallID,
+		ResourceID:       opts.ResourceID,
+		ResourceType:     opts.ResourceType,
+		LastActionDate:   *NewFlexDate(now),
+		LastActionType:   opts.LastActionType,
+		FieldName:        opts.FieldName,
+		PreviousValue:    opts.PreviousValue,
+		ReplacementValue: opts.ReplacementValue,
+		UpdatedBy:        opts.UpdatedBy,
 	}
 
 	return metadata, nil
@@ -1016,15 +1155,19 @@ type MetaTableDescriptionOptions struct {
 
 // NewMetaTableDescription creates a new MetaTableDescription with optional fields
 func NewMetaTableDescription(opts *MetaTableDescriptionOptions) (*MetaTableDescription, error) {
-	now := getICalTime()
-	id := newUUIDV4()
+	return defaultFactory.NewMetaTableDescription(opts)
+}
 
-	if !ValidateUUID(id) {
-		return nil, fmt.Errorf("failed to generate valid UUIDv4")
+// NewMetaTableDescription creates a new MetaTableDescription using the factory's Clock and IDGenerator
+func (f *Factory) NewMetaTableDescription(opts *MetaTableDescriptionOptions) (*MetaTableDescription, error) {
+	now := f.now()
+	id, err := f.newID(ResourceTypeMetaTableDescription)
+	if err != nil {
+		return nil, err
 	}
 
 	metaTableDesc := &MetaTableDescription{
-		CreatedAt: now,
+		CreatedAt: FlexTime{now},
 		ID:        id,
 	}
 
@@ -0,0 +1,334 @@
+package hsds_types
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// MappingRelation is a SKOS mapping property between terms of two taxonomies
+type MappingRelation string
+
+// MappingRelation values, in order of preference when translating
+const (
+	MappingExact    MappingRelation = "exactMatch"
+	MappingClose    MappingRelation = "closeMatch"
+	MappingBroader  MappingRelation = "broadMatch"
+	MappingNarrower MappingRelation = "narrowMatch"
+	MappingRelated  MappingRelation = "relatedMatch"
+)
+
+// mappingPreference ranks relations, lower is preferred
+var mappingPreference = map[MappingRelation]int{
+	MappingExact:    0,
+	MappingClose:    1,
+	MappingBroader:  2,
+	MappingNarrower: 3,
+	MappingRelated:  4,
+}
+
+// mappingRelationAliases accepts SKOS names with or without prefix and plain words
+var mappingRelationAliases = map[string]MappingRelation{
+	"exactmatch":   MappingExact,
+	"exact":        MappingExact,
+	"closematch":   MappingClose,
+	"close":        MappingClose,
+	"broadmatch":   MappingBroader,
+	"broader":      MappingBroader,
+	"broad":        MappingBroader,
+	"narrowmatch":  MappingNarrower,
+	"narrower":     MappingNarrower,
+	"narrow":       MappingNarrower,
+	"relatedmatch": MappingRelated,
+	"related":      MappingRelated,
+}
+
+// TermMapping links a term of the source taxonomy to a term of the target taxonomy
+type TermMapping struct {
+	SourceTermID string          `json:"source_term_id"`
+	TargetTermID string          `json:"target_term_id"`
+	Relation     MappingRelation `json:"relation"`
+}
+
+// Crosswalk translates terms from one Taxonomy to another
+type Crosswalk struct {
+	SourceTaxonomyID string
+	TargetTaxonomyID string
+	mappings         map[string][]TermMapping
+}
+
+// NewCrosswalk creates a Crosswalk from explicit term mappings
+func NewCrosswalk(sourceTaxonomyID, targetTaxonomyID string, mappings []TermMapping) (*Crosswalk, error) {
+	c := &Crosswalk{
+		SourceTaxonomyID: sourceTaxonomyID,
+		TargetTaxonomyID: targetTaxonomyID,
+		mappings:         make(map[string][]TermMapping),
+	}
+	for _, m := range mappings {
+		if _, ok := mappingPreference[m.Relation]; !ok {
+			return nil, fmt.Errorf("unknown mapping relation %q for term %s", m.Relation, m.SourceTermID)
+		}
+		c.mappings[m.SourceTermID] = append(c.mappings[m.SourceTermID], m)
+	}
+	return c, nil
+}
+
+// LoadCrosswalk reads mappings from CSV with the header
+// source_code,target_code,relation and resolves the codes against the terms
+// of the two taxonomies in ds. Relations may be SKOS names ("skos:broadMatch")
+// or plain words ("exact", "broader", "narrower").
+func LoadCrosswalk(r io.Reader, ds *Dataset, sourceTaxonomyID, targetTaxonomyID string) (*Crosswalk, error) {
+	sourceCodes := termCodes(ds, sourceTaxonomyID)
+	targetCodes := termCodes(ds, targetTaxonomyID)
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"source_code", "target_code", "relation"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var mappings []TermMapping
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading line %d: %w", line, err)
+		}
+
+		sourceCode := strings.TrimSpace(record[columns["source_code"]])
+		targetCode := strings.TrimSpace(record[columns["target_code"]])
+		relationName := strings.ToLower(strings.TrimSpace(record[columns["relation"]]))
+		relationName = strings.TrimPrefix(relationName, "skos:")
+
+		relation, ok := mappingRelationAliases[relationName]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown relation %q", line, record[columns["relation"]])
+		}
+		sourceID, ok := sourceCodes[sourceCode]
+		if !ok {
+			return nil, fmt.Errorf("line %d: source code %s not found in taxonomy %s", line, sourceCode, sourceTaxonomyID)
+		}
+		targetID, ok := targetCodes[targetCode]
+		if !ok {
+			return nil, fmt.Errorf("line %d: target code %s not found in taxonomy %s", line, targetCode, targetTaxonomyID)
+		}
+
+		mappings = append(mappings, TermMapping{SourceTermID: sourceID, TargetTermID: targetID, Relation: relation})
+	}
+
+	return NewCrosswalk(sourceTaxonomyID, targetTaxonomyID, mappings)
+}
+
+// Lookup returns the mappings of a source term with the most preferred
+// relation among those allowed
+func (c *Crosswalk) Lookup(sourceTermID string, allowed []MappingRelation) []TermMapping {
+	best := -1
+	var result []TermMapping
+	for _, m := range c.mappings[sourceTermID] {
+		if len(allowed) > 0 && !containsRelation(allowed, m.Relation) {
+			continue
+		}
+		rank := mappingPreference[m.Relation]
+		switch {
+		case best < 0 || rank < best:
+			best = rank
+			result = []TermMapping{m}
+		case rank == best:
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// CrosswalkOptions contains optional settings for Crosswalk.Translate
+type CrosswalkOptions struct {
+	// Relations limits which mappings are used, default exact, close and broader
+	Relations []MappingRelation
+	// KeepSource keeps the original attributes alongside the translated ones
+	KeepSource bool
+	// FallbackToAncestors maps an unmapped term through its nearest mapped ancestor
+	FallbackToAncestors bool
+	// Factory creates the translated attributes' IDs and timestamps, default the package Factory
+	Factory *Factory
+}
+
+// UnmappedTerm is a source term in use on the dataset with no usable mapping
+type UnmappedTerm struct {
+	TermID     string  `json:"term_id"`
+	Code       *string `json:"code,omitempty"`
+	Name       string  `json:"name"`
+	Attributes int     `json:"attributes"`
+}
+
+// CrosswalkReport summarises a Translate run
+type CrosswalkReport struct {
+	Translated []Attribute    `json:"translated"`
+	Removed    []Attribute    `json:"removed,omitempty"`
+	Unmapped   []UnmappedTerm `json:"unmapped"`
+}
+
+// Translate rewrites the Attribute rows on ds tagged with source taxonomy
+// terms into target taxonomy terms and reports source terms with no mapping.
+// Every mapping used must target a term of the target taxonomy in ds. On
+// error ds is left unchanged.
+func (c *Crosswalk) Translate(ds *Dataset, opts *CrosswalkOptions) (*CrosswalkReport, error) {
+	if ds == nil {
+		return nil, fmt.Errorf("dataset is required")
+	}
+	if opts == nil {
+		opts = &CrosswalkOptions{}
+	}
+	allowed := opts.Relations
+	if len(allowed) == 0 {
+		allowed = []MappingRelation{MappingExact, MappingClose, MappingBroader}
+	}
+
+	var sourceTerms []TaxonomyTerm
+	for _, term := range ds.TaxonomyTerms {
+		if matchesID(term.TaxonomyID, c.SourceTaxonomyID) {
+			sourceTerms = append(sourceTerms, term)
+		}
+	}
+	tree, err := NewTaxonomyTree(sourceTerms)
+	if err != nil {
+		return nil, err
+	}
+
+	targetTerms := make(map[string]bool)
+	for _, term := range ds.TaxonomyTerms {
+		if matchesID(term.TaxonomyID, c.TargetTaxonomyID) {
+			targetTerms[term.ID] = true
+		}
+	}
+
+	existing := make(map[string]bool)
+	for _, attr := range ds.Attributes {
+		existing[attr.TaxonomyTermID+"|"+attr.LinkEntity+"|"+attr.LinkID] = true
+	}
+
+	report := &CrosswalkReport{}
+	unmapped := make(map[string]int)
+	kept := ds.Attributes[:0:0]
+	f := factoryOr(opts.Factory)
+	now := f.now()
+
+	for _, attr := range ds.Attributes {
+		if tree.Term(attr.TaxonomyTermID) == nil {
+			kept = append(kept, attr)
+			continue
+		}
+
+		mappings := c.Lookup(attr.TaxonomyTermID, allowed)
+		if len(mappings) == 0 && opts.FallbackToAncestors {
+			for _, ancestor := range tree.Ancestors(attr.TaxonomyTermID) {
+				if mappings = c.Lookup(ancestor.ID, allowed); len(mappings) > 0 {
+					break
+				}
+			}
+		}
+		if len(mappings) == 0 {
+			unmapped[attr.TaxonomyTermID]++
+			kept = append(kept, attr)
+			continue
+		}
+
+		if opts.KeepSource {
+			kept = append(kept, attr)
+		} else {
+			report.Removed = append(report.Removed, attr)
+		}
+		for _, m := range mappings {
+			if !targetTerms[m.TargetTermID] {
+				return nil, fmt.Errorf("term %s maps to %s, which is not a term of taxonomy %s", m.SourceTermID, m.TargetTermID, c.TargetTaxonomyID)
+			}
+			key := m.TargetTermID + "|" + attr.LinkEntity + "|" + attr.LinkID
+			if existing[key] {
+				continue
+			}
+			existing[key] = true
+			id, err := translatedAttributeID(f, attr.ID, m.TargetTermID)
+			if err != nil {
+				return nil, err
+			}
+			translated := Attribute{
+				CreatedAt:      FlexTime{now},
+				ID:             id,
+				TaxonomyTermID: m.TargetTermID,
+				LinkID:         attr.LinkID,
+				LinkType:       attr.LinkType,
+				LinkEntity:     attr.LinkEntity,
+				Value:          attr.Value,
+				Label:          attr.Label,
+			}
+			report.Translated = append(report.Translated, translated)
+		}
+	}
+
+	ds.Attributes = append(kept, report.Translated...)
+
+	for id, count := range unmapped {
+		term := tree.Term(id)
+		report.Unmapped = append(report.Unmapped, UnmappedTerm{TermID: id, Code: term.Code, Name: term.Name, Attributes: count})
+	}
+	sort.Slice(report.Unmapped, func(i, j int) bool {
+		if report.Unmapped[i].Attributes != report.Unmapped[j].Attributes {
+			return report.Unmapped[i].Attributes > report.Unmapped[j].Attributes
+		}
+		return report.Unmapped[i].TermID < report.Unmapped[j].TermID
+	})
+
+	return report, nil
+}
+
+// translatedAttributeID returns the ID of the attribute translating
+// sourceAttributeID to targetTermID. A DerivedIDGenerator names one record per
+// type, so with one the ID is derived from the source attribute and target
+// term instead, and stays the same across runs.
+func translatedAttributeID(f *Factory, sourceAttributeID, targetTermID string) (string, error) {
+	if g, ok := f.IDs.(*DerivedIDGenerator); ok {
+		id, err := DeriveID(g.Namespace, sourceAttributeID+"/"+targetTermID, ResourceTypeAttribute)
+		if err == nil && !f.validUUID(id) {
+			err = fmt.Errorf("failed to generate valid UUID: %q", id)
+		}
+		return id, err
+	}
+	return f.newID(ResourceTypeAttribute)
+}
+
+// termCodes maps the codes of a taxonomy's terms to their IDs
+func termCodes(ds *Dataset, taxonomyID string) map[string]string {
+	codes := make(map[string]string)
+	if ds == nil {
+		return codes
+	}
+	for _, term := range ds.TaxonomyTerms {
+		if matchesID(term.TaxonomyID, taxonomyID) && term.Code != nil {
+			codes[*term.Code] = term.ID
+		}
+	}
+	return codes
+}
+
+// containsRelation reports whether relations includes r
+func containsRelation(relations []MappingRelation, r MappingRelation) bool {
+	for _, v := range relations {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package hsds_types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// crosswalkDataset returns a source taxonomy (Food > Pantry, Legal) and a
+// target taxonomy (Groceries), with services tagged by source terms
+func crosswalkDataset() *Dataset {
+	src, dst := "tax-src", "tax-dst"
+	food := "src-food"
+	return &Dataset{
+		TaxonomyTerms: []TaxonomyTerm{
+			{ID: "src-food", TaxonomyID: &src, Code: strPtr("F"), Name: "Food"},
+			{ID: "src-pantry", TaxonomyID: &src, Code: strPtr("F-1"), Name: "Pantry", ParentID: &food},
+			{ID: "src-legal", TaxonomyID: &src, Code: strPtr("L"), Name: "Legal"},
+			{ID: "dst-groceries", TaxonomyID: &dst, Code: strPtr("100"), Name: "Groceries"},
+		},
+		Attributes: []Attribute{
+			{ID: testID(1), TaxonomyTermID: "src-food", LinkEntity: "service", LinkID: "s1"},
+			{ID: testID(2), TaxonomyTermID: "src-pantry", LinkEntity: "service", LinkID: "s2"},
+			{ID: testID(3), TaxonomyTermID: "src-legal", LinkEntity: "service", LinkID: "s3"},
+			{ID: testID(4), TaxonomyTermID: "other", LinkEntity: "service", LinkID: "s4"},
+		},
+	}
+}
+
+func TestLoadCrosswalk(t *testing.T) {
+	ds := crosswalkDataset()
+	data := "source_code,target_code,relation\nF,100,skos:closeMatch\nF,100,exact\nL,100,related\n"
+	c, err := LoadCrosswalk(strings.NewReader(data), ds, "tax-src", "tax-dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Lookup("src-food", nil); len(got) != 1 || got[0].Relation != MappingExact {
+		t.Errorf("Lookup(food) = %+v, want the exact match", got)
+	}
+	if got := c.Lookup("src-legal", []MappingRelation{MappingExact}); got != nil {
+		t.Errorf("Lookup(legal, exact) = %+v, want none", got)
+	}
+
+	for _, bad := range []string{
+		"source_code,target_code\nF,100\n",
+		"source_code,target_code,relation\nF,100,sameAs\n",
+		"source_code,target_code,relation\nX,100,exact\n",
+		"source_code,target_code,relation\nF,999,exact\n",
+	} {
+		if _, err := LoadCrosswalk(strings.NewReader(bad), ds, "tax-src", "tax-dst"); err == nil {
+			t.Errorf("LoadCrosswalk accepted %q", bad)
+		}
+	}
+}
+
+func TestCrosswalkTranslate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *CrosswalkOptions
+		translated []string
+		unmapped   []string
+		attributes int
+	}{
+		{"exact only", nil, []string{"s1"}, []string{"src-legal", "src-pantry"}, 4},
+		{"fallback to ancestors", &CrosswalkOptions{FallbackToAncestors: true}, []string{"s1", "s2"}, []string{"src-legal"}, 4},
+		{"keep source", &CrosswalkOptions{FallbackToAncestors: true, KeepSource: true}, []string{"s1", "s2"}, []string{"src-legal"}, 6},
+	}
+	for _, tt := range tests {
+		ds := crosswalkDataset()
+		c, err := NewCrosswalk("tax-src", "tax-dst", []TermMapping{{SourceTermID: "src-food", TargetTermID: "dst-groceries", Relation: MappingExact}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := c.Translate(ds, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var links, unmapped []string
+		for _, attr := range report.Translated {
+			links = append(links, attr.LinkID)
+		}
+		for _, u := range report.Unmapped {
+			unmapped = append(unmapped, u.TermID)
+		}
+		if !reflect.DeepEqual(links, tt.translated) || !reflect.DeepEqual(unmapped, tt.unmapped) || len(ds.Attributes) != tt.attributes {
+			t.Errorf("%s: translated %v, unmapped %v, attributes %d", tt.name, links, unmapped, len(ds.Attributes))
+		}
+	}
+}
+
+func TestCrosswalkTranslateDerivedIDs(t *testing.T) {
+	c, err := NewCrosswalk("tax-src", "tax-dst", []TermMapping{{SourceTermID: "src-food", TargetTermID: "dst-groceries", Relation: MappingExact}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	translate := func() []string {
+		ds := crosswalkDataset()
+		f := NewFactory(FixedClock{testTime}, NewDerivedIDGenerator("feed", "crosswalk"))
+		report, err := c.Translate(ds, &CrosswalkOptions{FallbackToAncestors: true, Factory: f})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, attr := range report.Translated {
+			ids = append(ids, attr.ID)
+		}
+		return ids
+	}
+	first := translate()
+	if len(first) != 2 || first[0] == first[1] {
+		t.Fatalf("translated IDs = %v, want two distinct", first)
+	}
+	if second := translate(); !reflect.DeepEqual(first, second) {
+		t.Errorf("re-run IDs = %v, want %v", second, first)
+	}
+}
+
+func TestCrosswalkTranslateRejectsForeignTarget(t *testing.T) {
+	ds := crosswalkDataset()
+	c, err := NewCrosswalk("tax-src", "tax-dst", []TermMapping{{SourceTermID: "src-food", TargetTermID: "src-legal", Relation: MappingExact}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Translate(ds, nil); err == nil || !strings.Contains(err.Error(), "not a term of taxonomy tax-dst") {
+		t.Errorf("error = %v, want the target taxonomy check", err)
+	}
+	if len(ds.Attributes) != 4 || ds.Attributes[0].TaxonomyTermID != "src-food" {
+		t.Errorf("attributes changed by a failed Translate: %+v", ds.Attributes)
+	}
+}
@@ -0,0 +1,86 @@
+package hsds_types
+
+// Dataset bundles every record of an HSDS feed, one slice per table
+type Dataset struct {
+	Organizations           []Organization           `json:"organizations,omitempty"`
+	OrganizationIdentifiers []OrganizationIdentifier `json:"organization_identifiers,omitempty"`
+	URLs                    []URL                    `json:"urls,omitempty"`
+	Fundings                []Funding                `json:"funding,omitempty"`
+	Units                   []Unit                   `json:"units,omitempty"`
+	Programs                []Program                `json:"programs,omitempty"`
+	Services                []Service                `json:"services,omitempty"`
+	ServiceAreas            []ServiceArea            `json:"service_areas,omitempty"`
+	ServiceAtLocations      []ServiceAtLocation      `json:"service_at_locations,omitempty"`
+	Locations               []Location               `json:"locations,omitempty"`
+	Addresses               []Address                `json:"addresses,omitempty"`
+	RequiredDocuments       []RequiredDocument       `json:"required_documents,omitempty"`
+	Languages               []Language               `json:"languages,omitempty"`
+	Accessibilities         []Accessibility          `json:"accessibility,omitempty"`
+	Attributes              []Attribute              `json:"attributes,omitempty"`
+	Taxonomies              []Taxonomy               `json:"taxonomies,omitempty"`
+	TaxonomyTerms           []TaxonomyTerm           `json:"taxonomy_terms,omitempty"`
+	Contacts                []Contact                `json:"contacts,omitempty"`
+	Phones                  []Phone                  `json:"phones,omitempty"`
+	Schedules               []Schedule               `json:"schedules,omitempty"`
+	ServiceCapacities       []ServiceCapacity        `json:"service_capacities,omitempty"`
+	CostOptions             []CostOption             `json:"cost_options,omitempty"`
+	Metadata                []Metadata               `json:"metadata,omitempty"`
+	MetaTableDescriptions   []MetaTableDescription   `json:"meta_table_descriptions,omitempty"`
+}
+
+// OrganizationByID returns the organization with the given ID, or nil if absent
+func (ds *Dataset) OrganizationByID(id string) *Organization {
+	for i := range ds.Organizations {
+		if ds.Organizations[i].ID == id {
+			return &ds.Organizations[i]
+		}
+	}
+	return nil
+}
+
+// ServiceByID returns the service with the given ID, or nil if absent
+func (ds *Dataset) ServiceByID(id string) *Service {
+	for i := range ds.Services {
+		if ds.Services[i].ID == id {
+			return &ds.Services[i]
+		}
+	}
+	return nil
+}
+
+// LocationByID returns the location with the given ID, or nil if absent
+func (ds *Dataset) LocationByID(id string) *Location {
+	for i := range ds.Locations {
+		if ds.Locations[i].ID == id {
+			return &ds.Locations[i]
+		}
+	}
+	return nil
+}
+
+// ServiceAtLocationsFor returns the service_at_location rows linked to a location
+func (ds *Dataset) ServiceAtLocationsFor(locationID string) []ServiceAtLocation {
+	var result []ServiceAtLocation
+	for _, sal := range ds.ServiceAtLocations {
+		if sal.LocationID == locationID {
+			result = append(result, sal)
+		}
+	}
+	return result
+}
+
+// AddressesFor returns the addresses linked to a location
+func (ds *Dataset) AddressesFor(locationID string) []Address {
+	var result []Address
+	for _, addr := range ds.Addresses {
+		if matchesID(addr.LocationID, locationID) {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// matchesID reports whether an optional foreign key points at id
+func matchesID(fk *string, id string) bool {
+	return fk != nil && *fk == id
+}
@@ -0,0 +1,343 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordDecoder streams the records of a JSON array one at a time without
+// loading the whole array. Every time.Time field accepts any of TimeFormats
+// and is checked against its expected kind in DefaultTimeParser.Fields. Use it like bufio.Scanner:
+//
+//	dec := NewRecordDecoder[Service](r)
+//	for dec.Next() {
+//		svc := dec.Record()
+//		...
+//	}
+//	if err := dec.Err(); err != nil {
+//		...
+//	}
+type RecordDecoder[T any] struct {
+	dec     *json.Decoder
+	started bool
+	done    bool
+	index   int
+	record  T
+	err     error
+}
+
+// NewRecordDecoder creates a RecordDecoder reading a JSON array from r
+func NewRecordDecoder[T any](r io.Reader) *RecordDecoder[T] {
+	return &RecordDecoder[T]{dec: json.NewDecoder(r)}
+}
+
+// Next decodes the next record, returning false at the end of the array or on error
+func (d *RecordDecoder[T]) Next() bool {
+	if d.done {
+		return false
+	}
+	if !d.started {
+		d.started = true
+		tok, err := d.dec.Token()
+		if err != nil {
+			return d.fail(fmt.Errorf("reading array start: %w", err))
+		}
+		if tok == nil {
+			d.done = true
+			return false
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return d.fail(fmt.Errorf("expected a JSON array, got %v", tok))
+		}
+	}
+
+	if !d.dec.More() {
+		if _, err := d.dec.Token(); err != nil {
+			return d.fail(fmt.Errorf("reading array end: %w", err))
+		}
+		d.done = true
+		return false
+	}
+
+	var record T
+	if err := decodeFlexible(d.dec, reflect.ValueOf(&record).Elem(), ""); err != nil {
+		return d.fail(fmt.Errorf("record %d: %w", d.index, err))
+	}
+	d.record = record
+	d.index++
+	return true
+}
+
+// Record returns the record decoded by the last call to Next
+func (d *RecordDecoder[T]) Record() T {
+	return d.record
+}
+
+// Err returns the error that stopped decoding, if any
+func (d *RecordDecoder[T]) Err() error {
+	return d.err
+}
+
+// fail stops the decoder with err
+func (d *RecordDecoder[T]) fail(err error) bool {
+	d.err = err
+	d.done = true
+	return false
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// decodeFlexible decodes the next JSON value of field into v, walking structs
+// token by token so time fields can be parsed with DefaultTimeParser; other
+// values, and types with their own UnmarshalJSON, use the standard decoder
+func decodeFlexible(dec *json.Decoder, v reflect.Value, field string) error {
+	t := v.Type()
+	switch {
+	case t == timeType:
+		return decodeTime(dec, v, field)
+	case t.Kind() == reflect.Pointer && t.Elem() == timeType:
+		return decodePointer(dec, v, field)
+	case t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType):
+		return dec.Decode(v.Addr().Interface())
+	case t.Kind() == reflect.Struct:
+		return decodeStruct(dec, v)
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		return decodePointer(dec, v, field)
+	default:
+		return dec.Decode(v.Addr().Interface())
+	}
+}
+
+// decodePointer reads null, or a time or object, into a pointer to a time or
+// struct. Peeking requires reading the token, so the opened value is handed
+// to the element decoder.
+func decodePointer(dec *json.Decoder, v reflect.Value, field string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	elem := reflect.New(v.Type().Elem())
+	if v.Type().Elem() == timeType {
+		err = setTimeToken(elem.Elem(), tok, field)
+	} else {
+		err = decodeStructBody(dec, elem.Elem(), tok)
+	}
+	if err != nil {
+		return err
+	}
+	v.Set(elem)
+	return nil
+}
+
+// decodeTime reads a time string, or null, into v
+func decodeTime(dec *json.Decoder, v reflect.Value, field string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	return setTimeToken(v, tok, field)
+}
+
+// setTimeToken parses a string token as the kind expected for field into v
+func setTimeToken(v reflect.Value, tok json.Token, field string) error {
+	s, ok := tok.(string)
+	if !ok {
+		return fmt.Errorf("expected a time string, got %v", tok)
+	}
+	parsed, err := DefaultTimeParser.ParseField(field, s)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(parsed))
+	return nil
+}
+
+// decodeStruct reads a JSON object, or null, into the struct v
+func decodeStruct(dec *json.Decoder, v reflect.Value) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	return decodeStructBody(dec, v, tok)
+}
+
+// decodeStructBody reads the members of an object whose opening token has been consumed
+func decodeStructBody(dec *json.Decoder, v reflect.Value, open json.Token) error {
+	if delim, ok := open.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object for %s, got %v", v.Type(), open)
+	}
+	fields := jsonFieldsOf(v.Type())
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		field, ok := fields[key]
+		if !ok {
+			field, ok = fields[strings.ToLower(key)]
+		}
+		if !ok {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decodeFlexible(dec, fieldByIndexAlloc(v, field.index), field.name); err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// jsonFieldCache holds jsonFieldsOf results per struct type
+var jsonFieldCache sync.Map
+
+// jsonField is a struct field found by its JSON name, possibly promoted from
+// an embedded struct
+type jsonField struct {
+	index  []int
+	name   string
+	tagged bool
+}
+
+// jsonFieldsOf maps the JSON names of a struct's exported fields, and their
+// lower-case forms, to fields. Fields of embedded structs are promoted by the
+// rules of encoding/json: the shallowest wins, then a tagged one, and names
+// left ambiguous are ignored.
+func jsonFieldsOf(t reflect.Type) map[string]jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.(map[string]jsonField)
+	}
+
+	byName := make(map[string][]jsonField)
+	var names []string
+	visited := map[reflect.Type]bool{t: true}
+	level := []jsonField{{}}
+	for len(level) > 0 {
+		var next []jsonField
+		depth := make(map[string][]jsonField)
+		for _, parent := range level {
+			st := t
+			if len(parent.index) > 0 {
+				st = t.FieldByIndex(parent.index).Type
+				if st.Kind() == reflect.Pointer {
+					st = st.Elem()
+				}
+			}
+			for i := 0; i < st.NumField(); i++ {
+				f := st.Field(i)
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				// an unexported embedded struct pointer cannot be allocated
+				if !f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct) {
+					continue
+				}
+				tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+				if tag == "-" {
+					continue
+				}
+				index := append(append([]int(nil), parent.index...), i)
+				if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+					if !visited[ft] {
+						visited[ft] = true
+						next = append(next, jsonField{index: index})
+					}
+					continue
+				}
+				if !f.IsExported() {
+					continue
+				}
+				name := tag
+				if name == "" {
+					name = f.Name
+				}
+				depth[name] = append(depth[name], jsonField{index: index, name: name, tagged: tag != ""})
+			}
+		}
+		for name, candidates := range depth {
+			if _, shadowed := byName[name]; !shadowed {
+				names = append(names, name)
+				byName[name] = candidates
+			}
+		}
+		level = next
+	}
+
+	fields := make(map[string]jsonField)
+	for _, name := range names {
+		if f, ok := dominantField(byName[name]); ok {
+			fields[name] = f
+		}
+	}
+	for _, name := range names {
+		f, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if lower := strings.ToLower(name); lower != name {
+			if _, exists := fields[lower]; !exists {
+				fields[lower] = f
+			}
+		}
+	}
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
+
+// dominantField picks the field a JSON name decodes into among those found at
+// the same depth: the only one, or the only tagged one
+func dominantField(candidates []jsonField) (jsonField, bool) {
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	var tagged []jsonField
+	for _, f := range candidates {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return jsonField{}, false
+}
+
+// fieldByIndexAlloc returns the field at index, allocating nil embedded
+// struct pointers on the way
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
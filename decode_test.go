@@ -0,0 +1,278 @@
+package hsds_types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timedRecord has plain, pointer and nested time.Time fields
+type timedRecord struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   *time.Time   `json:"updated_at"`
+	AssuredDate *time.Time   `json:"assured_date"`
+	Source      *timedSource `json:"source"`
+	Tags        []string     `json:"tags"`
+}
+
+type timedSource struct {
+	Name         string    `json:"name"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+func TestUnmarshalJSONWithTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		check   func(r timedRecord) string
+		wantErr string
+	}{
+		{
+			name: "mixed layouts",
+			data: `[{"id":"a","created_at":"2024-03-10 09:30:00","updated_at":"2024-03-10T10:00:00-08:00","assured_date":"2024-03-10T23:00:00Z"}]`,
+			check: func(r timedRecord) string {
+				if !r.CreatedAt.Equal(time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)) {
+					return fmt.Sprintf("created_at = %v", r.CreatedAt)
+				}
+				if !r.UpdatedAt.Equal(time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC)) {
+					return fmt.Sprintf("updated_at = %v", r.UpdatedAt)
+				}
+				if !r.AssuredDate.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) {
+					return fmt.Sprintf("assured_date = %v, want the date only", r.AssuredDate)
+				}
+				return ""
+			},
+		},
+		{
+			name: "null times",
+			data: `[{"id":"a","created_at":null,"updated_at":null,"source":null}]`,
+			check: func(r timedRecord) string {
+				if !r.CreatedAt.IsZero() || r.UpdatedAt != nil || r.Source != nil {
+					return fmt.Sprintf("record = %+v, want zero times and nil pointers", r)
+				}
+				return ""
+			},
+		},
+		{
+			name: "nested pointer struct",
+			data: `[{"id":"a","source":{"name":"feed","last_modified":"2024-03-10"},"tags":["x"]}]`,
+			check: func(r timedRecord) string {
+				if r.Source == nil || !r.Source.LastModified.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) {
+					return fmt.Sprintf("source = %+v", r.Source)
+				}
+				return ""
+			},
+		},
+		{
+			name: "unknown and differently cased keys",
+			data: `[{"ID":"a","Name":"Pantry","extra":{"nested":[1,2]}}]`,
+			check: func(r timedRecord) string {
+				if r.ID != "a" || r.Name != "Pantry" {
+					return fmt.Sprintf("record = %+v", r)
+				}
+				return ""
+			},
+		},
+		{name: "unparseable time", data: `[{"id":"a","created_at":"soon"}]`, wantErr: "field created_at"},
+		{name: "time of day for a timestamp", data: `[{"id":"a","created_at":"09:30:00"}]`, wantErr: "no date"},
+		{name: "not an array", data: `{"id":"a"}`, wantErr: "expected a JSON array"},
+		{name: "number for a time", data: `[{"created_at":5}]`, wantErr: "expected a time string"},
+	}
+	for _, tt := range tests {
+		var records []timedRecord
+		err := UnmarshalJSONWithTime([]byte(tt.data), &records)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(records) != 1 {
+			t.Errorf("%s: records = %d, want 1", tt.name, len(records))
+			continue
+		}
+		if msg := tt.check(records[0]); msg != "" {
+			t.Errorf("%s: %s", tt.name, msg)
+		}
+	}
+}
+
+func TestRecordDecoderFlexTypes(t *testing.T) {
+	data := `[
+		{"id":"s1","name":"Pantry","created_at":"2024-03-10 09:30:00","updated_at":null,"assured_date":"2024-03-10T12:00:00Z"},
+		{"id":"s2","name":"Clinic"}
+	]`
+	dec := NewRecordDecoder[Service](strings.NewReader(data))
+	var got []Service
+	for dec.Next() {
+		got = append(got, dec.Record())
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("records = %d, want 2", len(got))
+	}
+	if !got[0].CreatedAt.Equal(time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)) || !got[0].UpdatedAt.IsZero() {
+		t.Errorf("timestamps = %v, %v", got[0].CreatedAt, got[0].UpdatedAt)
+	}
+	if got[0].AssuredDate == nil || got[0].AssuredDate.String() != "2024-03-10" {
+		t.Errorf("assured_date = %v, want 2024-03-10", got[0].AssuredDate)
+	}
+	if got[1].AssuredDate != nil {
+		t.Errorf("absent assured_date = %v, want nil", got[1].AssuredDate)
+	}
+
+	empty := NewRecordDecoder[Service](strings.NewReader(`null`))
+	if empty.Next() || empty.Err() != nil {
+		t.Errorf("null input: Next or Err = %v", empty.Err())
+	}
+}
+
+// embeddedRecord decodes id, name and created_at through embedded structs
+type embeddedBase struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type embeddedAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type embeddedRecord struct {
+	embeddedBase
+	embeddedAudit
+	Title string `json:"title"`
+}
+
+func TestUnmarshalJSONWithTimeEmbedded(t *testing.T) {
+	var got []embeddedRecord
+	data := `[{"id":"x","name":"Food Bank","created_at":"2024-03-10 09:30:00","title":"Pantry"}]`
+	if err := UnmarshalJSONWithTime([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	var want []embeddedRecord
+	if err := json.Unmarshal([]byte(`[{"id":"x","name":"Food Bank","created_at":"2024-03-10T09:30:00Z","title":"Pantry"}]`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "x" || got[0].Name != "Food Bank" || got[0].Title != "Pantry" {
+		t.Fatalf("records = %+v", got)
+	}
+	if !got[0].CreatedAt.Equal(want[0].CreatedAt) {
+		t.Errorf("promoted created_at = %v, want %v", got[0].CreatedAt, want[0].CreatedAt)
+	}
+}
+
+func TestUnmarshalJSONWithTimeFreshSlice(t *testing.T) {
+	backing := []timedRecord{{ID: "kept"}}
+	result := backing[:0]
+	if err := UnmarshalJSONWithTime([]byte(`[{"id":"new"}]`), &result); err != nil {
+		t.Fatal(err)
+	}
+	if backing[0].ID != "kept" || len(result) != 1 || result[0].ID != "new" {
+		t.Errorf("caller's array = %+v, result = %+v", backing, result)
+	}
+}
+
+// benchmarkFeed returns a JSON array of n timed records using mixed layouts
+func benchmarkFeed(n int) []byte {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":"rec-%d","name":"Service %d","created_at":"2024-03-10 09:30:00","updated_at":"2024-03-10T10:00:00.123456-08:00","assured_date":"2024-03-10","source":{"name":"feed","last_modified":"2024-03-09T08:00:00Z"},"tags":["food","pantry"],"notes":"unused field"}`, i, i)
+	}
+	b.WriteByte(']')
+	return b.Bytes()
+}
+
+// legacyUnmarshalJSONWithTime is the earlier decode-convert-reencode
+// implementation, kept as the baseline for BenchmarkUnmarshalJSONWithTime
+func legacyUnmarshalJSONWithTime[T any](data []byte, result *[]T) error {
+	var rawData []map[string]interface{}
+	if err := json.Unmarshal(data, &rawData); err != nil {
+		return fmt.Errorf("unmarshalling raw data: %w", err)
+	}
+	*result = make([]T, len(rawData))
+	for i, raw := range rawData {
+		legacyConvertTimeFields(raw)
+		jsonData, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("marshalling processed data: %w", err)
+		}
+		if err := json.Unmarshal(jsonData, &(*result)[i]); err != nil {
+			return fmt.Errorf("unmarshalling to target type: %w", err)
+		}
+	}
+	return nil
+}
+
+func legacyConvertTimeFields(data map[string]interface{}) {
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			for _, tf := range StandardTimeFields {
+				if key == tf {
+					if t, err := ParseTime(v); err == nil {
+						data[key] = t
+					}
+					break
+				}
+			}
+		case map[string]interface{}:
+			legacyConvertTimeFields(v)
+		}
+	}
+}
+
+// BenchmarkUnmarshalJSONWithTime compares the streaming decoder with the
+// legacy triple decode; run with -benchmem to compare allocations
+func BenchmarkUnmarshalJSONWithTime(b *testing.B) {
+	data := benchmarkFeed(2000)
+	impls := []struct {
+		name string
+		fn   func([]byte, *[]timedRecord) error
+	}{
+		{"legacy", legacyUnmarshalJSONWithTime[timedRecord]},
+		{"streaming", UnmarshalJSONWithTime[timedRecord]},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var records []timedRecord
+				if err := impl.fn(data, &records); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRecordDecoder(b *testing.B) {
+	data := benchmarkFeed(2000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dec := NewRecordDecoder[timedRecord](bytes.NewReader(data))
+		n := 0
+		for dec.Next() {
+			n++
+		}
+		if err := dec.Err(); err != nil || n != 2000 {
+			b.Fatalf("decoded %d records: %v", n, err)
+		}
+	}
+}
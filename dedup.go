@@ -0,0 +1,449 @@
+package hsds_types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// nameStopwords are dropped before comparing organization, service and location names
+var nameStopwords = makeSet("the", "a", "an", "of", "and", "inc", "incorporated", "llc", "ltd", "corp", "corporation", "co", "company", "org", "organization")
+
+// DedupOptions contains optional settings for duplicate detection
+type DedupOptions struct {
+	// Threshold is the minimum score for a pair to be reported, default 0.85
+	Threshold float64
+	// GeohashPrecision is the geohash length used for location blocking, default 7 (~150m)
+	GeohashPrecision int
+	// DefaultRegion is used to normalize phone numbers, default "US"
+	DefaultRegion string
+}
+
+// DuplicateMatch explains why two records were judged to be duplicates
+type DuplicateMatch struct {
+	A       string   `json:"a"`
+	B       string   `json:"b"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// DuplicateCluster groups records of one table that likely describe the same thing
+type DuplicateCluster struct {
+	Entity  string           `json:"entity"`
+	IDs     []string         `json:"ids"`
+	Matches []DuplicateMatch `json:"matches"`
+}
+
+// dedupRecord is the comparable view of an organization, service or location
+type dedupRecord struct {
+	id        string
+	scope     string
+	name      string
+	taxID     string
+	addresses []string
+	blockKeys []string
+}
+
+// FindDuplicates returns clusters of likely duplicate organizations, services and locations
+func FindDuplicates(ds *Dataset, opts *DedupOptions) ([]DuplicateCluster, error) {
+	if ds == nil {
+		return nil, fmt.Errorf("dataset is required")
+	}
+
+	var clusters []DuplicateCluster
+	clusters = append(clusters, FindDuplicateOrganizations(ds, opts)...)
+	clusters = append(clusters, FindDuplicateServices(ds, opts)...)
+	clusters = append(clusters, FindDuplicateLocations(ds, opts)...)
+	return clusters, nil
+}
+
+// FindDuplicateOrganizations clusters organizations blocked by tax ID, phone,
+// location geohash and normalized name. A nil dataset has no duplicates.
+func FindDuplicateOrganizations(ds *Dataset, opts *DedupOptions) []DuplicateCluster {
+	if ds == nil {
+		return nil
+	}
+	opts = dedupDefaults(opts)
+	phones := phonesBy(ds, opts, func(p *Phone) *string { return p.OrganizationID })
+
+	records := make([]dedupRecord, 0, len(ds.Organizations))
+	for _, org := range ds.Organizations {
+		rec := dedupRecord{id: org.ID, name: normalizeName(org.Name)}
+		if org.TaxID != nil {
+			rec.taxID = digitsOnly(*org.TaxID)
+		}
+		for _, loc := range ds.Locations {
+			if matchesID(loc.OrganizationID, org.ID) {
+				rec.addresses = append(rec.addresses, locationAddressKeys(ds, loc.ID)...)
+				rec.blockKeys = append(rec.blockKeys, locationGeohashKeys(loc, opts)...)
+			}
+		}
+		rec.blockKeys = append(rec.blockKeys, phones[org.ID]...)
+		if rec.taxID != "" {
+			rec.blockKeys = append(rec.blockKeys, "tax:"+rec.taxID)
+		}
+		records = append(records, rec)
+	}
+
+	return clusterRecords("organizations", records, opts)
+}
+
+// FindDuplicateServices clusters services of the same organization blocked
+// by phone, geohash of the locations they are offered at, and normalized
+// name. Services of different organizations are never compared. A nil
+// dataset has no duplicates.
+func FindDuplicateServices(ds *Dataset, opts *DedupOptions) []DuplicateCluster {
+	if ds == nil {
+		return nil
+	}
+	opts = dedupDefaults(opts)
+	phones := phonesBy(ds, opts, func(p *Phone) *string { return p.ServiceID })
+
+	records := make([]dedupRecord, 0, len(ds.Services))
+	for _, svc := range ds.Services {
+		rec := dedupRecord{id: svc.ID, scope: svc.OrganizationID, name: normalizeName(svc.Name)}
+		for _, sal := range ds.ServiceAtLocations {
+			if sal.ServiceID != svc.ID {
+				continue
+			}
+			rec.addresses = append(rec.addresses, locationAddressKeys(ds, sal.LocationID)...)
+			if loc := ds.LocationByID(sal.LocationID); loc != nil {
+				rec.blockKeys = append(rec.blockKeys, locationGeohashKeys(*loc, opts)...)
+			}
+		}
+		rec.blockKeys = append(rec.blockKeys, phones[svc.ID]...)
+		records = append(records, rec)
+	}
+
+	return clusterRecords("services", records, opts)
+}
+
+// FindDuplicateLocations clusters locations blocked by geohash, phone, postal
+// address and normalized name. A nil dataset has no duplicates.
+func FindDuplicateLocations(ds *Dataset, opts *DedupOptions) []DuplicateCluster {
+	if ds == nil {
+		return nil
+	}
+	opts = dedupDefaults(opts)
+	phones := phonesBy(ds, opts, func(p *Phone) *string { return p.LocationID })
+
+	records := make([]dedupRecord, 0, len(ds.Locations))
+	for _, loc := range ds.Locations {
+		rec := dedupRecord{id: loc.ID}
+		if loc.Name != nil {
+			rec.name = normalizeName(*loc.Name)
+		}
+		rec.addresses = locationAddressKeys(ds, loc.ID)
+		for _, addr := range rec.addresses {
+			rec.blockKeys = append(rec.blockKeys, "address:"+addr)
+		}
+		rec.blockKeys = append(rec.blockKeys, locationGeohashKeys(loc, opts)...)
+		rec.blockKeys = append(rec.blockKeys, phones[loc.ID]...)
+		records = append(records, rec)
+	}
+
+	return clusterRecords("locations", records, opts)
+}
+
+// clusterRecords compares every pair in the same scope sharing a block key
+// and unions pairs scoring at or above the threshold into clusters
+func clusterRecords(entity string, records []dedupRecord, opts *DedupOptions) []DuplicateCluster {
+	type block struct{ scope, key string }
+	blocks := make(map[block][]int)
+	for i := range records {
+		if records[i].name != "" {
+			records[i].blockKeys = append(records[i].blockKeys, "name:"+records[i].name)
+		}
+		for _, key := range uniqueStrings(records[i].blockKeys) {
+			b := block{records[i].scope, key}
+			blocks[b] = append(blocks[b], i)
+		}
+	}
+
+	type pair struct{ a, b int }
+	shared := make(map[pair][]string)
+	for b, members := range blocks {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				p := pair{members[x], members[y]}
+				shared[p] = append(shared[p], b.key)
+			}
+		}
+	}
+
+	parent := make([]int, len(records))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	var pairs []pair
+	var matches []DuplicateMatch
+	for p, keys := range shared {
+		score, reasons := scorePair(&records[p.a], &records[p.b], keys)
+		if score < opts.Threshold {
+			continue
+		}
+		parent[find(p.a)] = find(p.b)
+		pairs = append(pairs, p)
+		matches = append(matches, DuplicateMatch{A: records[p.a].id, B: records[p.b].id, Score: score, Reasons: reasons})
+	}
+
+	groups := make(map[int]*DuplicateCluster)
+	for i, p := range pairs {
+		root := find(p.a)
+		cluster, ok := groups[root]
+		if !ok {
+			cluster = &DuplicateCluster{Entity: entity}
+			groups[root] = cluster
+		}
+		cluster.Matches = append(cluster.Matches, matches[i])
+	}
+
+	result := make([]DuplicateCluster, 0, len(groups))
+	for _, cluster := range groups {
+		ids := make(map[string]bool)
+		for _, m := range cluster.Matches {
+			ids[m.A] = true
+			ids[m.B] = true
+		}
+		for id := range ids {
+			cluster.IDs = append(cluster.IDs, id)
+		}
+		sort.Strings(cluster.IDs)
+		sort.Slice(cluster.Matches, func(i, j int) bool {
+			if cluster.Matches[i].A != cluster.Matches[j].A {
+				return cluster.Matches[i].A < cluster.Matches[j].A
+			}
+			return cluster.Matches[i].B < cluster.Matches[j].B
+		})
+		result = append(result, *cluster)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].IDs[0] < result[j].IDs[0] })
+
+	return result
+}
+
+// scorePair combines name and address similarity with evidence from shared block keys
+func scorePair(a, b *dedupRecord, sharedKeys []string) (float64, []string) {
+	var reasons []string
+
+	nameSim := 0.0
+	if a.name != "" && b.name != "" {
+		nameSim = nameSimilarity(a.name, b.name)
+		reasons = append(reasons, fmt.Sprintf("name similarity %.2f", nameSim))
+	}
+
+	score := nameSim
+	if len(a.addresses) > 0 && len(b.addresses) > 0 {
+		addrSim := 0.0
+		for _, x := range a.addresses {
+			for _, y := range b.addresses {
+				if s := jaroWinkler(x, y); s > addrSim {
+					addrSim = s
+				}
+			}
+		}
+		reasons = append(reasons, fmt.Sprintf("address similarity %.2f", addrSim))
+		if a.name != "" && b.name != "" {
+			score = 0.6*nameSim + 0.4*addrSim
+		} else {
+			score = addrSim
+		}
+	}
+
+	sort.Strings(sharedKeys)
+	for _, key := range sharedKeys {
+		kind, value, _ := strings.Cut(key, ":")
+		switch kind {
+		case "phone":
+			score += 0.1
+			reasons = append(reasons, "shared phone "+value)
+		case "tax":
+			score += 0.2
+			reasons = append(reasons, "same tax ID")
+		case "geohash":
+			score += 0.05
+			reasons = append(reasons, "within geohash "+value)
+		case "address":
+			reasons = append(reasons, "same address")
+		}
+	}
+
+	if a.taxID != "" && b.taxID != "" && a.taxID != b.taxID {
+		score *= 0.5
+		reasons = append(reasons, "different tax IDs")
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+// dedupDefaults fills unset options
+func dedupDefaults(opts *DedupOptions) *DedupOptions {
+	result := DedupOptions{}
+	if opts != nil {
+		result = *opts
+	}
+	if result.Threshold == 0 {
+		result.Threshold = 0.85
+	}
+	if result.GeohashPrecision == 0 {
+		result.GeohashPrecision = 7
+	}
+	if result.DefaultRegion == "" {
+		result.DefaultRegion = "US"
+	}
+	return &result
+}
+
+// phonesBy returns normalized "phone:" block keys grouped by the selected foreign key
+func phonesBy(ds *Dataset, opts *DedupOptions, fk func(*Phone) *string) map[string][]string {
+	result := make(map[string][]string)
+	for i := range ds.Phones {
+		owner := fk(&ds.Phones[i])
+		if owner == nil {
+			continue
+		}
+		number := ds.Phones[i].Number
+		if normalized, err := NormalizePhone(number, opts.DefaultRegion); err == nil {
+			number = normalized.Number
+		}
+		result[*owner] = append(result[*owner], "phone:"+number)
+	}
+	return result
+}
+
+// locationAddressKeys returns normalized single-line addresses for a location
+func locationAddressKeys(ds *Dataset, locationID string) []string {
+	var keys []string
+	for _, addr := range ds.AddressesFor(locationID) {
+		addr.Normalize()
+		keys = append(keys, strings.Join([]string{addr.Address1, addr.City, addr.StateProvince, zip5(addr.PostalCode)}, " "))
+	}
+	return keys
+}
+
+// locationGeohashKeys returns the "geohash:" block key for a location with coordinates
+func locationGeohashKeys(loc Location, opts *DedupOptions) []string {
+	if loc.Latitude == nil || loc.Longitude == nil {
+		return nil
+	}
+	return []string{"geohash:" + Geohash(*loc.Latitude, *loc.Longitude, opts.GeohashPrecision)}
+}
+
+// normalizeName lower-cases a name, strips punctuation and drops stopwords
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, w := range words {
+		if !nameStopwords[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// nameSimilarity scores two normalized names, taking the better of
+// Jaro-Winkler on the full string and on the sorted token sets
+func nameSimilarity(a, b string) float64 {
+	direct := jaroWinkler(a, b)
+	ta, tb := strings.Fields(a), strings.Fields(b)
+	sort.Strings(ta)
+	sort.Strings(tb)
+	if sorted := jaroWinkler(strings.Join(ta, " "), strings.Join(tb, " ")); sorted > direct {
+		return sorted
+	}
+	return direct
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings in [0, 1]
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// digitsOnly keeps only the digits of s, so "EIN 91-1234567" and "911234567"
+// compare equal
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// uniqueStrings returns values with duplicates removed, preserving order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
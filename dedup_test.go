@@ -0,0 +1,130 @@
+package hsds_types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicateServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []Service
+		want     [][]string
+	}{
+		{
+			name: "same name in the same organization",
+			services: []Service{
+				{ID: "s1", OrganizationID: "o1", Name: "Food Pantry"},
+				{ID: "s2", OrganizationID: "o1", Name: "The Food Pantry"},
+			},
+			want: [][]string{{"s1", "s2"}},
+		},
+		{
+			name: "same name in different organizations",
+			services: []Service{
+				{ID: "s1", OrganizationID: "o1", Name: "Food Pantry"},
+				{ID: "s2", OrganizationID: "o2", Name: "Food Pantry"},
+			},
+		},
+		{
+			name: "different names in the same organization",
+			services: []Service{
+				{ID: "s1", OrganizationID: "o1", Name: "Food Pantry"},
+				{ID: "s2", OrganizationID: "o1", Name: "Legal Aid"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		clusters := FindDuplicateServices(&Dataset{Services: tt.services}, nil)
+		var got [][]string
+		for _, c := range clusters {
+			got = append(got, c.IDs)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: clusters = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicateOrganizations(t *testing.T) {
+	tax := func(s string) *string { return &s }
+	tests := []struct {
+		name string
+		orgs []Organization
+		want [][]string
+	}{
+		{
+			name: "name variants",
+			orgs: []Organization{{ID: "o1", Name: "Food Bank, Inc."}, {ID: "o2", Name: "The Food Bank"}},
+			want: [][]string{{"o1", "o2"}},
+		},
+		{
+			name: "same tax ID with a prefix",
+			orgs: []Organization{{ID: "o1", Name: "Food Bank", TaxID: tax("EIN 91-1234567")}, {ID: "o2", Name: "Food Bank", TaxID: tax("911234567")}},
+			want: [][]string{{"o1", "o2"}},
+		},
+		{
+			name: "similar names with different tax IDs",
+			orgs: []Organization{{ID: "o1", Name: "Food Bank", TaxID: tax("12-3456789")}, {ID: "o2", Name: "Food Bank", TaxID: tax("98-7654321")}},
+		},
+		{
+			name: "unrelated names",
+			orgs: []Organization{{ID: "o1", Name: "Food Bank"}, {ID: "o2", Name: "Legal Aid Society"}},
+		},
+	}
+	for _, tt := range tests {
+		clusters := FindDuplicateOrganizations(&Dataset{Organizations: tt.orgs}, nil)
+		var got [][]string
+		for _, c := range clusters {
+			got = append(got, c.IDs)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: clusters = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicatesNilDataset(t *testing.T) {
+	if FindDuplicateOrganizations(nil, nil) != nil || FindDuplicateServices(nil, nil) != nil || FindDuplicateLocations(nil, nil) != nil {
+		t.Error("a nil dataset has duplicates")
+	}
+	if _, err := FindDuplicates(nil, nil); err == nil {
+		t.Error("FindDuplicates accepted a nil dataset")
+	}
+}
+
+func TestFindDuplicateLocations(t *testing.T) {
+	l1, l2, l3 := "l1", "l2", "l3"
+	ds := &Dataset{
+		Locations: []Location{{ID: l1}, {ID: l2}, {ID: l3}},
+		Addresses: []Address{
+			{ID: "a1", LocationID: &l1, Address1: "123 Main Street", City: "Seattle", StateProvince: "WA", PostalCode: "98101", Country: "US"},
+			{ID: "a2", LocationID: &l2, Address1: "123 Main St.", City: "Seattle", StateProvince: "Washington", PostalCode: "98101-1234", Country: "US"},
+			{ID: "a3", LocationID: &l3, Address1: "900 Pine St", City: "Seattle", StateProvince: "WA", PostalCode: "98101", Country: "US"},
+		},
+	}
+	clusters := FindDuplicateLocations(ds, nil)
+	if len(clusters) != 1 || !reflect.DeepEqual(clusters[0].IDs, []string{"l1", "l2"}) {
+		t.Fatalf("clusters = %+v, want l1 and l2", clusters)
+	}
+	if clusters[0].Entity != "locations" || len(clusters[0].Matches) != 1 {
+		t.Errorf("cluster = %+v", clusters[0])
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dixon", "dicksonx", 0.813},
+		{"same", "same", 1},
+		{"", "x", 0},
+	}
+	for _, tt := range tests {
+		if got := jaroWinkler(tt.a, tt.b); got < tt.want-0.001 || got > tt.want+0.001 {
+			t.Errorf("jaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package hsds_types
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// sourceNamespace seeds the per-source namespaces of derived IDs
+var sourceNamespace = uuid.MustParse("9d4f3b1a-2c7e-4e8b-b6a5-0f1e2d3c4b5a")
+
+// DeriveID returns a stable UUIDv5 for a record imported from an external
+// source, computed from the source namespace (a UUID, or any name such as a
+// feed URL), the record's ID in that source and the HSDS table it lands in.
+// Re-importing the same feed yields the same IDs, so upserts can match on ID.
+func DeriveID(namespace, sourceRecordID string, entityType MetadataResourceTypeEnum) (string, error) {
+	if strings.TrimSpace(namespace) == "" {
+		return "", fmt.Errorf("source namespace is required")
+	}
+	if strings.TrimSpace(sourceRecordID) == "" {
+		return "", fmt.Errorf("source record ID is required")
+	}
+	if err := ValidateResourceType(string(entityType)); err != nil {
+		return "", err
+	}
+
+	ns, err := uuid.Parse(namespace)
+	if err != nil {
+		ns = uuid.NewSHA1(sourceNamespace, []byte(namespace))
+	}
+	return uuid.NewSHA1(ns, []byte(string(entityType)+"/"+sourceRecordID)).String(), nil
+}
+
+// DerivedIDGenerator derives the IDs of the records created from one source
+// record, one per entity type, for use with NewFactory:
+//
+//	f := NewFactory(nil, NewDerivedIDGenerator(feed, row.ID))
+//	org, err := f.NewOrganization(row.Name, row.Description, nil)
+//
+// Each call derives the ID from the type of the record being created, so an
+// organization and a service imported from the same row get different IDs.
+// A second record of the same type is an error rather than a duplicate key;
+// use a new generator per source record. Metadata rows, and callers that do
+// not name an entity type, get IDs from Fallback.
+type DerivedIDGenerator struct {
+	Namespace      string
+	SourceRecordID string
+	// Fallback generates IDs not derived from the source, default UUIDv4Generator
+	Fallback IDGenerator
+
+	mu     sync.Mutex
+	issued map[MetadataResourceTypeEnum]bool
+}
+
+// NewDerivedIDGenerator creates a DerivedIDGenerator for one source record
+func NewDerivedIDGenerator(namespace, sourceRecordID string) *DerivedIDGenerator {
+	return &DerivedIDGenerator{Namespace: namespace, SourceRecordID: sourceRecordID}
+}
+
+// NewID returns an ID from Fallback, since no entity type is known
+func (g *DerivedIDGenerator) NewID() string {
+	if g.Fallback == nil {
+		return newUUIDV4()
+	}
+	return g.Fallback.NewID()
+}
+
+// NewEntityID derives the ID of the source record's entityType record
+func (g *DerivedIDGenerator) NewEntityID(entityType MetadataResourceTypeEnum) (string, error) {
+	if entityType == ResourceTypeMetadata {
+		return g.NewID(), nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.issued[entityType] {
+		return "", fmt.Errorf("source record %q already derived a %s ID", g.SourceRecordID, entityType)
+	}
+	id, err := DeriveID(g.Namespace, g.SourceRecordID, entityType)
+	if err != nil {
+		return "", err
+	}
+	if g.issued == nil {
+		g.issued = make(map[MetadataResourceTypeEnum]bool)
+	}
+	g.issued[entityType] = true
+	return id, nil
+}
@@ -0,0 +1,132 @@
+package hsds_types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeriveID(t *testing.T) {
+	a, err := DeriveID("https://feeds.example.org/211", "org-17", ResourceTypeOrganization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ValidateUUID(a, 5) {
+		t.Errorf("DeriveID = %s, want a UUIDv5", a)
+	}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		sourceID   string
+		entityType MetadataResourceTypeEnum
+		same       bool
+	}{
+		{"same inputs", "https://feeds.example.org/211", "org-17", ResourceTypeOrganization, true},
+		{"other record", "https://feeds.example.org/211", "org-18", ResourceTypeOrganization, false},
+		{"other table", "https://feeds.example.org/211", "org-17", ResourceTypeService, false},
+		{"other source", "https://feeds.example.org/511", "org-17", ResourceTypeOrganization, false},
+	}
+	for _, tt := range tests {
+		b, err := DeriveID(tt.namespace, tt.sourceID, tt.entityType)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if (a == b) != tt.same {
+			t.Errorf("%s: DeriveID = %s, first = %s, want same %v", tt.name, b, a, tt.same)
+		}
+	}
+
+	for _, bad := range [][3]string{{"", "x", "organization"}, {"ns", " ", "organization"}, {"ns", "x", "widget"}} {
+		if _, err := DeriveID(bad[0], bad[1], MetadataResourceTypeEnum(bad[2])); err == nil {
+			t.Errorf("DeriveID(%q, %q, %q) succeeded", bad[0], bad[1], bad[2])
+		}
+	}
+}
+
+func TestDerivedIDGeneratorDerivesPerEntityType(t *testing.T) {
+	f := NewFactory(nil, NewDerivedIDGenerator("feed", "row-1"))
+	org, err := f.NewOrganization("Food Bank", "Groceries", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := f.NewService(org.ID, "Pantry", ServiceStatusActive, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org.ID == svc.ID {
+		t.Fatalf("organization and service share ID %s", org.ID)
+	}
+	if want, _ := DeriveID("feed", "row-1", ResourceTypeService); svc.ID != want {
+		t.Errorf("service ID = %s, want %s", svc.ID, want)
+	}
+
+	_, err = f.NewOrganization("Food Bank", "Groceries", nil)
+	if err == nil || !strings.Contains(err.Error(), "already derived") {
+		t.Errorf("second organization error = %v, want a duplicate derivation error", err)
+	}
+
+	meta, err := f.NewMetadata(MetadataOptions{
+		ResourceID:     org.ID,
+		ResourceType:   ResourceTypeOrganization,
+		LastActionType: ActionTypeCreate,
+		FieldName:      "name",
+	})
+	if err != nil {
+		t.Fatalf("NewMetadata: %v", err)
+	}
+	if !ValidateUUID(meta.ID, 4) {
+		t.Errorf("metadata ID = %s, want a fallback UUIDv4", meta.ID)
+	}
+}
+
+func TestNewMetadataResourceIDVersions(t *testing.T) {
+	v5, _ := DeriveID("feed", "row-1", ResourceTypeOrganization)
+	opts := MetadataOptions{ResourceID: v5, ResourceType: ResourceTypeOrganization, LastActionType: ActionTypeCreate, FieldName: "name"}
+	if _, err := NewFactory(nil, nil).NewMetadata(opts); err != nil {
+		t.Errorf("default factory rejected a UUIDv5 resource ID: %v", err)
+	}
+	strict := NewFactory(nil, nil)
+	strict.UUIDVersions = []int{4}
+	if _, err := strict.NewMetadata(opts); err == nil {
+		t.Error("a factory accepting only UUIDv4 took a UUIDv5 resource ID")
+	}
+
+	meta, err := NewMetadata(v5, "call-1", "organization", "create", "name", "", "Food Bank", "importer")
+	if err != nil {
+		t.Fatalf("deprecated NewMetadata: %v", err)
+	}
+	if meta.ResourceType != ResourceTypeOrganization || meta.ReplacementValue != "Food Bank" || meta.UpdatedBy != "importer" {
+		t.Errorf("deprecated NewMetadata = %+v", meta)
+	}
+}
+
+func TestValidateUUIDVersions(t *testing.T) {
+	v4 := "00000000-0000-4000-8000-000000000001"
+	v5, _ := DeriveID("feed", "row-1", ResourceTypeOrganization)
+	v1 := "00000000-0000-1000-8000-000000000001"
+
+	tests := []struct {
+		id       string
+		versions []int
+		want     bool
+	}{
+		{v4, nil, true},
+		{v5, nil, false},
+		{v5, DefaultUUIDVersions(), true},
+		{v1, nil, false},
+		{v5, []int{4}, false},
+		{v1, []int{1}, true},
+		{"not-a-uuid", nil, false},
+	}
+	for _, tt := range tests {
+		if got := ValidateUUID(tt.id, tt.versions...); got != tt.want {
+			t.Errorf("ValidateUUID(%s, %v) = %v, want %v", tt.id, tt.versions, got, tt.want)
+		}
+	}
+
+	versions := DefaultUUIDVersions()
+	versions[0] = 1
+	if NewFactory(nil, nil).validUUID(v1) {
+		t.Error("modifying the DefaultUUIDVersions result changed the defaults")
+	}
+}
@@ -0,0 +1,348 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// tableOrder lists the tables so that every table comes after the tables it
+// references; patches insert in this order and delete in reverse
+var tableOrder = []MetadataResourceTypeEnum{
+	ResourceTypeOrganization,
+	ResourceTypeOrganizationIdentifier,
+	ResourceTypeUnit,
+	ResourceTypeProgram,
+	ResourceTypeService,
+	ResourceTypeURL,
+	ResourceTypeFunding,
+	ResourceTypeLocation,
+	ResourceTypeServiceAtLocation,
+	ResourceTypeServiceArea,
+	ResourceTypeAddress,
+	ResourceTypeRequiredDocument,
+	ResourceTypeContact,
+	ResourceTypePhone,
+	ResourceTypeLanguage,
+	ResourceTypeAccessibility,
+	ResourceTypeSchedule,
+	ResourceTypeServiceCapacity,
+	ResourceTypeCostOption,
+	ResourceTypeTaxonomy,
+	ResourceTypeTaxonomyTerm,
+	ResourceTypeAttribute,
+	ResourceTypeMetadata,
+	ResourceTypeMetaTableDescription,
+}
+
+// datasetTables maps each resource type to the index of its Dataset slice
+var datasetTables = func() map[MetadataResourceTypeEnum]int {
+	tables := make(map[MetadataResourceTypeEnum]int)
+	t := reflect.TypeOf(Dataset{})
+	for i := 0; i < t.NumField(); i++ {
+		tables[MetadataResourceTypeEnum(resourceTypeOf(t.Field(i).Type.Elem()))] = i
+	}
+	return tables
+}()
+
+// RecordDiff is one added, removed or modified record
+type RecordDiff struct {
+	ID string `json:"id"`
+	// Record is the full record for additions and removals
+	Record any `json:"record,omitempty"`
+	// Changes lists the modified fields
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// TableDiff holds the differences within one table
+type TableDiff struct {
+	Table    MetadataResourceTypeEnum `json:"table"`
+	Added    []RecordDiff             `json:"added,omitempty"`
+	Removed  []RecordDiff             `json:"removed,omitempty"`
+	Modified []RecordDiff             `json:"modified,omitempty"`
+}
+
+// DatasetDiff lists the tables that differ between two datasets
+type DatasetDiff struct {
+	Tables []TableDiff `json:"tables"`
+}
+
+// DiffDatasets compares two snapshots of a feed table by table, matching
+// records by ID. Records are modified when Diff reports a field change.
+func DiffDatasets(a, b *Dataset) *DatasetDiff {
+	if a == nil {
+		a = &Dataset{}
+	}
+	if b == nil {
+		b = &Dataset{}
+	}
+
+	diff := &DatasetDiff{}
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for _, table := range tableOrder {
+		ta, tb := va.Field(datasetTables[table]), vb.Field(datasetTables[table])
+		td := TableDiff{Table: table}
+
+		old := make(map[string]reflect.Value, ta.Len())
+		for i := 0; i < ta.Len(); i++ {
+			old[ta.Index(i).FieldByName("ID").String()] = ta.Index(i)
+		}
+		seen := make(map[string]bool, tb.Len())
+		for i := 0; i < tb.Len(); i++ {
+			record := tb.Index(i)
+			id := record.FieldByName("ID").String()
+			seen[id] = true
+			previous, ok := old[id]
+			if !ok {
+				td.Added = append(td.Added, RecordDiff{ID: id, Record: record.Interface()})
+				continue
+			}
+			if changes := Diff(previous.Interface(), record.Interface()); len(changes) > 0 {
+				td.Modified = append(td.Modified, RecordDiff{ID: id, Changes: changes})
+			}
+		}
+		for i := 0; i < ta.Len(); i++ {
+			record := ta.Index(i)
+			if id := record.FieldByName("ID").String(); !seen[id] {
+				td.Removed = append(td.Removed, RecordDiff{ID: id, Record: record.Interface()})
+			}
+		}
+
+		if len(td.Added)+len(td.Removed)+len(td.Modified) > 0 {
+			sortRecordDiffs(td.Added)
+			sortRecordDiffs(td.Removed)
+			sortRecordDiffs(td.Modified)
+			diff.Tables = append(diff.Tables, td)
+		}
+	}
+	return diff
+}
+
+// PatchOp is the kind of a PatchOperation
+type PatchOp string
+
+// PatchOp values
+const (
+	PatchAdd    PatchOp = "add"
+	PatchRemove PatchOp = "remove"
+	PatchUpdate PatchOp = "update"
+)
+
+// PatchOperation is one step of a DatasetPatch
+type PatchOperation struct {
+	Op    PatchOp                  `json:"op"`
+	Table MetadataResourceTypeEnum `json:"table"`
+	ID    string                   `json:"id"`
+	// Record is the JSON of the record to add
+	Record json.RawMessage `json:"record,omitempty"`
+	// Changes are the fields to update
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// DatasetPatch is a serializable list of operations that brings a store from
+// one snapshot to the next. Removals come first, children before parents,
+// then additions and updates, parents before children.
+type DatasetPatch struct {
+	Operations []PatchOperation `json:"operations"`
+}
+
+// PatchStore is a destination a DatasetPatch can be applied to. Dataset
+// implements it; database-backed stores can use ApplyFieldChanges for updates.
+type PatchStore interface {
+	Insert(table MetadataResourceTypeEnum, record any) error
+	Delete(table MetadataResourceTypeEnum, id string) error
+	Update(table MetadataResourceTypeEnum, id string, changes []FieldChange) error
+}
+
+// Patch converts the diff into a DatasetPatch
+func (d *DatasetDiff) Patch() (*DatasetPatch, error) {
+	patch := &DatasetPatch{}
+	for i := len(d.Tables) - 1; i >= 0; i-- {
+		for _, rd := range d.Tables[i].Removed {
+			patch.Operations = append(patch.Operations, PatchOperation{Op: PatchRemove, Table: d.Tables[i].Table, ID: rd.ID})
+		}
+	}
+	for _, td := range d.Tables {
+		for _, rd := range td.Added {
+			record, err := json.Marshal(rd.Record)
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s %s: %w", td.Table, rd.ID, err)
+			}
+			patch.Operations = append(patch.Operations, PatchOperation{Op: PatchAdd, Table: td.Table, ID: rd.ID, Record: record})
+		}
+		for _, rd := range td.Modified {
+			patch.Operations = append(patch.Operations, PatchOperation{Op: PatchUpdate, Table: td.Table, ID: rd.ID, Changes: rd.Changes})
+		}
+	}
+	return patch, nil
+}
+
+// Apply runs the operations against store in order, stopping at the first error
+func (p *DatasetPatch) Apply(store PatchStore) error {
+	for i, op := range p.Operations {
+		index, ok := datasetTables[op.Table]
+		if !ok {
+			return fmt.Errorf("operation %d: unknown table %q", i, op.Table)
+		}
+
+		var err error
+		switch op.Op {
+		case PatchAdd:
+			record := reflect.New(reflect.TypeOf(Dataset{}).Field(index).Type.Elem())
+			if err = json.Unmarshal(op.Record, record.Interface()); err == nil {
+				err = store.Insert(op.Table, record.Interface())
+			}
+		case PatchRemove:
+			err = store.Delete(op.Table, op.ID)
+		case PatchUpdate:
+			err = store.Update(op.Table, op.ID, op.Changes)
+		default:
+			err = fmt.Errorf("unknown op %q", op.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("operation %d (%s %s %s): %w", i, op.Op, op.Table, op.ID, err)
+		}
+	}
+	return nil
+}
+
+// Insert adds a record, given as a value or pointer, to its table
+func (ds *Dataset) Insert(table MetadataResourceTypeEnum, record any) error {
+	rows, err := ds.table(table)
+	if err != nil {
+		return err
+	}
+	v := recordValue(record)
+	if !v.IsValid() || v.Type() != rows.Type().Elem() {
+		return fmt.Errorf("%T is not a %s record", record, table)
+	}
+	id := v.FieldByName("ID").String()
+	if indexOfID(rows, id) >= 0 {
+		return fmt.Errorf("%s %s already exists", table, id)
+	}
+	rows.Set(reflect.Append(rows, v))
+	return nil
+}
+
+// Delete removes a record from its table
+func (ds *Dataset) Delete(table MetadataResourceTypeEnum, id string) error {
+	rows, err := ds.table(table)
+	if err != nil {
+		return err
+	}
+	i := indexOfID(rows, id)
+	if i < 0 {
+		return fmt.Errorf("%s %s not found", table, id)
+	}
+	rows.Set(reflect.AppendSlice(rows.Slice(0, i), rows.Slice(i+1, rows.Len())))
+	return nil
+}
+
+// Update applies field changes to a record and bumps its UpdatedAt
+func (ds *Dataset) Update(table MetadataResourceTypeEnum, id string, changes []FieldChange) error {
+	return ds.update(defaultFactory, table, id, changes)
+}
+
+// Store returns ds as a PatchStore whose updates are stamped with the factory's clock
+func (f *Factory) Store(ds *Dataset) PatchStore {
+	return &factoryStore{Dataset: ds, factory: f}
+}
+
+// factoryStore is a Dataset that stamps updates with a Factory's clock
+type factoryStore struct {
+	*Dataset
+	factory *Factory
+}
+
+// Update applies field changes to a record and bumps its UpdatedAt
+func (s *factoryStore) Update(table MetadataResourceTypeEnum, id string, changes []FieldChange) error {
+	return s.Dataset.update(s.factory, table, id, changes)
+}
+
+// update applies field changes to a record and sets its UpdatedAt to f's time
+func (ds *Dataset) update(f *Factory, table MetadataResourceTypeEnum, id string, changes []FieldChange) error {
+	rows, err := ds.table(table)
+	if err != nil {
+		return err
+	}
+	i := indexOfID(rows, id)
+	if i < 0 {
+		return fmt.Errorf("%s %s not found", table, id)
+	}
+	record := rows.Index(i).Addr().Interface()
+	if err := ApplyFieldChanges(record, changes); err != nil {
+		return err
+	}
+	if u := rows.Index(i).FieldByName("UpdatedAt"); u.IsValid() {
+		u.Set(reflect.ValueOf(FlexTime{f.now()}))
+	}
+	return nil
+}
+
+// ApplyFieldChanges sets each changed field of record, a pointer to an HSDS
+// record, to its Replacement. Replacements may be typed values or their
+// decoded JSON form, so changes read back from a serialized patch apply too.
+func ApplyFieldChanges(record any, changes []FieldChange) error {
+	v := reflect.ValueOf(record)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T is not a pointer to an HSDS record", record)
+	}
+	v = v.Elem()
+
+	fields := make(map[string]int)
+	for _, field := range dataFields(v.Type()) {
+		fields[field.name] = field.index
+	}
+
+	for _, change := range changes {
+		index, ok := fields[change.Field]
+		if !ok {
+			return fmt.Errorf("%s has no field %q", v.Type().Name(), change.Field)
+		}
+		value, err := decodeFieldValue(v.Field(index).Type(), change.Replacement)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", change.Field, err)
+		}
+		v.Field(index).Set(value)
+	}
+	return nil
+}
+
+// decodeFieldValue converts a replacement value to type t via its JSON form;
+// the Flex time types parse every supported format themselves
+func decodeFieldValue(t reflect.Type, replacement any) (reflect.Value, error) {
+	data, err := json.Marshal(replacement)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return value.Elem(), nil
+}
+
+// table returns the addressable slice of a table
+func (ds *Dataset) table(table MetadataResourceTypeEnum) (reflect.Value, error) {
+	index, ok := datasetTables[table]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("unknown table %q", table)
+	}
+	return reflect.ValueOf(ds).Elem().Field(index), nil
+}
+
+// indexOfID returns the position of the record with the given ID in a table slice, or -1
+func indexOfID(rows reflect.Value, id string) int {
+	for i := 0; i < rows.Len(); i++ {
+		if rows.Index(i).FieldByName("ID").String() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortRecordDiffs orders record diffs by ID
+func sortRecordDiffs(diffs []RecordDiff) {
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].ID < diffs[j].ID })
+}
@@ -0,0 +1,136 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// snapshots returns yesterday's and today's feeds: one organization renamed,
+// one service added, one removed with its phone
+func snapshots() (*Dataset, *Dataset) {
+	org, s1, s2, s3, p1 := testID(1), testID(2), testID(3), testID(4), testID(5)
+	yesterday := &Dataset{
+		Organizations: []Organization{{ID: org, Name: "Food Bank", Description: "Groceries"}},
+		Services: []Service{
+			{ID: s1, OrganizationID: org, Name: "Pantry", Status: ServiceStatusActive},
+			{ID: s2, OrganizationID: org, Name: "Meals", Status: ServiceStatusActive},
+		},
+		Phones: []Phone{{ID: p1, ServiceID: &s2, Number: "+12065551212"}},
+	}
+	today := &Dataset{
+		Organizations: []Organization{{ID: org, Name: "Food Bank NW", Description: "Groceries", Email: strPtr("info@example.org")}},
+		Services: []Service{
+			{ID: s1, OrganizationID: org, Name: "Pantry", Status: ServiceStatusActive},
+			{ID: s3, OrganizationID: org, Name: "Clinic", Status: ServiceStatusActive},
+		},
+	}
+	return yesterday, today
+}
+
+func TestDiffDatasets(t *testing.T) {
+	yesterday, today := snapshots()
+	diff := DiffDatasets(yesterday, today)
+
+	type summary struct{ added, removed, modified []string }
+	got := make(map[MetadataResourceTypeEnum]summary)
+	for _, td := range diff.Tables {
+		var s summary
+		for _, rd := range td.Added {
+			s.added = append(s.added, rd.ID)
+		}
+		for _, rd := range td.Removed {
+			s.removed = append(s.removed, rd.ID)
+		}
+		for _, rd := range td.Modified {
+			s.modified = append(s.modified, rd.ID)
+		}
+		got[td.Table] = s
+	}
+	want := map[MetadataResourceTypeEnum]summary{
+		ResourceTypeOrganization: {modified: []string{testID(1)}},
+		ResourceTypeService:      {added: []string{testID(4)}, removed: []string{testID(3)}},
+		ResourceTypePhone:        {removed: []string{testID(5)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff = %+v, want %+v", got, want)
+	}
+
+	changes := diff.Tables[0].Modified[0].Changes
+	if len(changes) != 2 || changes[0].Field != "name" || changes[1].Field != "email" {
+		t.Errorf("organization changes = %+v, want name and email", changes)
+	}
+
+	if d := DiffDatasets(yesterday, yesterday.Clone()); len(d.Tables) != 0 {
+		t.Errorf("identical snapshots differ: %+v", d.Tables)
+	}
+}
+
+func TestDatasetPatchRoundTrip(t *testing.T) {
+	yesterday, today := snapshots()
+	patch, err := DiffDatasets(yesterday, today).Patch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ops []string
+	for _, op := range patch.Operations {
+		ops = append(ops, string(op.Op)+" "+string(op.Table))
+	}
+	wantOps := []string{"remove phone", "remove service", "update organization", "add service"}
+	if !reflect.DeepEqual(ops, wantOps) {
+		t.Errorf("operations = %q, want %q", ops, wantOps)
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded DatasetPatch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	store := yesterday.Clone()
+	if err := decoded.Apply(newTestFactory().Store(store)); err != nil {
+		t.Fatal(err)
+	}
+	if d := DiffDatasets(store, today); len(d.Tables) != 0 {
+		t.Errorf("patched snapshot still differs: %+v", d.Tables)
+	}
+	if !store.Organizations[0].UpdatedAt.Equal(testTime) {
+		t.Errorf("updated organization stamped %v, want %v", store.Organizations[0].UpdatedAt, testTime)
+	}
+}
+
+func TestDatasetPatchApplyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		op   PatchOperation
+	}{
+		{"unknown table", PatchOperation{Op: PatchRemove, Table: "widget", ID: testID(1)}},
+		{"unknown op", PatchOperation{Op: "upsert", Table: ResourceTypeService, ID: testID(1)}},
+		{"missing record", PatchOperation{Op: PatchRemove, Table: ResourceTypeService, ID: testID(9)}},
+		{"bad record JSON", PatchOperation{Op: PatchAdd, Table: ResourceTypeService, ID: testID(1), Record: json.RawMessage(`[]`)}},
+	}
+	for _, tt := range tests {
+		patch := &DatasetPatch{Operations: []PatchOperation{tt.op}}
+		if err := patch.Apply(&Dataset{}); err == nil {
+			t.Errorf("%s: Apply succeeded", tt.name)
+		}
+	}
+}
+
+func TestApplyFieldChangesLenientTimes(t *testing.T) {
+	var svc Service
+	changes := []FieldChange{{Field: "assured_date", Replacement: "2024-03-10 09:30:00"}}
+	if err := ApplyFieldChanges(&svc, changes); err != nil {
+		t.Fatal(err)
+	}
+	if svc.AssuredDate == nil || svc.AssuredDate.String() != "2024-03-10" {
+		t.Errorf("assured_date = %v, want 2024-03-10", svc.AssuredDate)
+	}
+	if err := ApplyFieldChanges(&svc, []FieldChange{{Field: "assured_date", Replacement: "soon"}}); err == nil {
+		t.Error("applied an unparseable date")
+	}
+}
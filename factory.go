@@ -0,0 +1,154 @@
+package hsds_types
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock supplies the CreatedAt timestamps of new records
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator supplies the IDs of new records
+type IDGenerator interface {
+	NewID() string
+}
+
+// EntityIDGenerator is an IDGenerator whose IDs depend on the type of the
+// record being created, such as DerivedIDGenerator
+type EntityIDGenerator interface {
+	IDGenerator
+	NewEntityID(entityType MetadataResourceTypeEnum) (string, error)
+}
+
+// SystemClock reads the current time in UTC, rounded to the second
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return getICalTime()
+}
+
+// FixedClock always returns the same time, for reproducible output
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the fixed time
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// UUIDv4Generator generates random UUIDv4 IDs
+type UUIDv4Generator struct{}
+
+// NewID returns a new UUIDv4
+func (UUIDv4Generator) NewID() string {
+	return newUUIDV4()
+}
+
+// UUIDv7Generator generates time-ordered UUIDv7 IDs
+type UUIDv7Generator struct{}
+
+// NewID returns a new UUIDv7
+func (UUIDv7Generator) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// SequenceIDGenerator generates predictable UUIDv4-shaped IDs from a counter,
+// e.g. 00000000-0000-4000-8000-000000000001, for golden-file tests
+type SequenceIDGenerator struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewSequenceIDGenerator creates a SequenceIDGenerator whose first ID is start
+func NewSequenceIDGenerator(start uint64) *SequenceIDGenerator {
+	return &SequenceIDGenerator{next: start}
+}
+
+// NewID returns the next ID in the sequence
+func (g *SequenceIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	id := fmt.Sprintf("00000000-0000-4000-8000-%012x", g.next)
+	g.next++
+	return id
+}
+
+// defaultUUIDVersions are the ID versions accepted unless configured
+var defaultUUIDVersions = []int{4, 5, 7}
+
+// DefaultUUIDVersions returns the ID versions a Factory accepts
+// unless configured: random v4, derived v5 and time-ordered v7
+func DefaultUUIDVersions() []int {
+	return append([]int(nil), defaultUUIDVersions...)
+}
+
+// Factory creates HSDS records with an injectable Clock and IDGenerator. It
+// exposes every New* constructor as a method; the package-level functions use
+// a Factory with the system clock and UUIDv4 IDs.
+type Factory struct {
+	Clock Clock
+	IDs   IDGenerator
+	// UUIDVersions lists the versions accepted for generated IDs and foreign
+	// keys, default DefaultUUIDVersions()
+	UUIDVersions []int
+}
+
+// defaultFactory backs the package-level constructors
+var defaultFactory = NewFactory(nil, nil)
+
+// NewFactory creates a Factory; a nil clock or ids falls back to SystemClock or UUIDv4Generator
+func NewFactory(clock Clock, ids IDGenerator) *Factory {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	if ids == nil {
+		ids = UUIDv4Generator{}
+	}
+	return &Factory{Clock: clock, IDs: ids}
+}
+
+// factoryOr returns f, or the package default Factory when f is nil
+func factoryOr(f *Factory) *Factory {
+	if f == nil {
+		return defaultFactory
+	}
+	return f
+}
+
+// now returns the factory's current time
+func (f *Factory) now() time.Time {
+	return f.Clock.Now()
+}
+
+// newID generates the ID of a record of entityType and checks that it is a
+// UUID the factory accepts
+func (f *Factory) newID(entityType MetadataResourceTypeEnum) (string, error) {
+	id := ""
+	if g, ok := f.IDs.(EntityIDGenerator); ok {
+		var err error
+		if id, err = g.NewEntityID(entityType); err != nil {
+			return "", err
+		}
+	} else {
+		id = f.IDs.NewID()
+	}
+	if !f.validUUID(id) {
+		return "", fmt.Errorf("failed to generate valid UUID: %q", id)
+	}
+	return id, nil
+}
+
+// validUUID reports whether u is a UUID of a version the factory accepts
+func (f *Factory) validUUID(u string) bool {
+	if len(f.UUIDVersions) == 0 {
+		return ValidateUUID(u, defaultUUIDVersions...)
+	}
+	return ValidateUUID(u, f.UUIDVersions...)
+}
@@ -0,0 +1,78 @@
+package hsds_types
+
+import (
+	"testing"
+	"time"
+)
+
+var testTime = time.Date(2024, 3, 3, 14, 0, 0, 0, time.UTC)
+
+// newTestFactory returns a Factory with a fixed clock and sequential IDs
+func newTestFactory() *Factory {
+	return NewFactory(FixedClock{testTime}, NewSequenceIDGenerator(1))
+}
+
+func TestFactoryConstructorsAreDeterministic(t *testing.T) {
+	f := newTestFactory()
+	org, err := f.NewOrganization("Food Bank", "Groceries", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := f.NewService(org.ID, "Pantry", ServiceStatusActive, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if org.ID != "00000000-0000-4000-8000-000000000001" || svc.ID != "00000000-0000-4000-8000-000000000002" {
+		t.Errorf("IDs = %s, %s; want sequence 1, 2", org.ID, svc.ID)
+	}
+	if !org.CreatedAt.Equal(testTime) || !svc.CreatedAt.Equal(testTime) {
+		t.Errorf("CreatedAt = %v, %v; want %v", org.CreatedAt, svc.CreatedAt, testTime)
+	}
+}
+
+func TestFactoryRejectsInvalidGeneratedIDs(t *testing.T) {
+	f := NewFactory(nil, fixedIDs("not-a-uuid"))
+	if _, err := f.NewOrganization("Food Bank", "Groceries", nil); err == nil {
+		t.Error("NewOrganization accepted a generated ID that is not a UUID")
+	}
+}
+
+func TestFactoryChangeSet(t *testing.T) {
+	f := newTestFactory()
+	cs := f.NewChangeSet("editor")
+	if cs.CallID != "00000000-0000-4000-8000-000000000001" || !cs.At.Equal(testTime) {
+		t.Errorf("change set = %s at %v, want the factory's ID and clock", cs.CallID, cs.At)
+	}
+
+	old := Organization{ID: "00000000-0000-4000-8000-0000000000aa", Name: "Old"}
+	updated := old
+	updated.Name = "New"
+	if err := cs.Track(&old, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if len(cs.Rows) != 1 {
+		t.Fatalf("rows = %d, want 1", len(cs.Rows))
+	}
+	if row := cs.Rows[0]; row.ID != "00000000-0000-4000-8000-000000000002" || !row.CreatedAt.Equal(testTime) {
+		t.Errorf("row = %s at %v, want the factory's ID and clock", row.ID, row.CreatedAt)
+	}
+}
+
+func TestFactoryStoreStampsUpdates(t *testing.T) {
+	ds := &Dataset{Organizations: []Organization{{ID: "o1", Name: "Old"}}}
+	store := newTestFactory().Store(ds)
+	if err := store.Update(ResourceTypeOrganization, "o1", []FieldChange{{Field: "name", Replacement: "New"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := ds.Organizations[0]; got.Name != "New" || !got.UpdatedAt.Equal(testTime) {
+		t.Errorf("organization = %q updated %v, want New updated %v", got.Name, got.UpdatedAt, testTime)
+	}
+}
+
+// fixedIDs is an IDGenerator that always returns the same ID
+type fixedIDs string
+
+func (id fixedIDs) NewID() string {
+	return string(id)
+}
@@ -0,0 +1,189 @@
+package hsds_types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ownershipFields are the reference fields that make a record part of the
+// record it points to, for DatasetFingerprint. References to shared lookup
+// records such as taxonomy terms and units are not ownership.
+var ownershipFields = []string{
+	"contact_id", "link_id", "location_id", "organization_id", "parent_id",
+	"parent_organization_id", "phone_id", "program_id",
+	"service_at_location_id", "service_id", "taxonomy_id",
+}
+
+// Fingerprint returns a SHA-256 hex digest of the business fields of an HSDS
+// record. The ID, timestamps and references to other records are left out, so
+// the same data imported under different IDs has the same fingerprint; use
+// DatasetFingerprint to count references.
+func Fingerprint(entity any) string {
+	v := recordValue(entity)
+	if !v.IsValid() {
+		return ""
+	}
+
+	fields := dataFields(v.Type())
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+
+	var b strings.Builder
+	b.WriteString(resourceTypeOf(v.Type()))
+	for _, field := range fields {
+		if contains(referenceFields, field.name) {
+			continue
+		}
+		name, _ := json.Marshal(field.name)
+		b.WriteByte('\n')
+		b.Write(name)
+		b.WriteByte(':')
+		value := reflect.ValueOf(derefValue(v.Field(field.index)))
+		if !value.IsValid() {
+			b.WriteString("null")
+			continue
+		}
+		encoded, _ := json.Marshal(formatFieldValue(value))
+		b.Write(encoded)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// DatasetFingerprint returns the fingerprint of entity combined with those of
+// every record in ds it owns, recursively: a service's fingerprint changes when
+// its phones, schedules or attributes change. References to other records
+// count by the Fingerprint of the record they point to, or by the ID when it
+// is not in ds, so moving a service to another organization changes it too.
+func DatasetFingerprint(ds *Dataset, entity any) string {
+	return newFingerprinter(ds).tree(recordValue(entity))
+}
+
+// DatasetFingerprints returns the DatasetFingerprint of every record in ds, keyed by ID
+func DatasetFingerprints(ds *Dataset) map[string]string {
+	f := newFingerprinter(ds)
+	result := make(map[string]string)
+	tables := reflect.ValueOf(ds).Elem()
+	for t := 0; t < tables.NumField(); t++ {
+		table := tables.Field(t)
+		for i := 0; i < table.Len(); i++ {
+			record := table.Index(i)
+			result[record.FieldByName("ID").String()] = f.tree(record)
+		}
+	}
+	return result
+}
+
+// fingerprinter memoizes tree fingerprints over one dataset
+type fingerprinter struct {
+	records  map[string]reflect.Value
+	children map[string][]reflect.Value
+	done     map[string]string
+	visiting map[string]bool
+}
+
+// newFingerprinter indexes the records of ds by ID and by the records that own them
+func newFingerprinter(ds *Dataset) *fingerprinter {
+	f := &fingerprinter{
+		records:  make(map[string]reflect.Value),
+		children: make(map[string][]reflect.Value),
+		done:     make(map[string]string),
+		visiting: make(map[string]bool),
+	}
+	if ds == nil {
+		return f
+	}
+
+	tables := reflect.ValueOf(ds).Elem()
+	for t := 0; t < tables.NumField(); t++ {
+		table := tables.Field(t)
+		if elem := table.Type().Elem(); elem == reflect.TypeOf(Metadata{}) || elem == reflect.TypeOf(MetaTableDescription{}) {
+			continue
+		}
+		for i := 0; i < table.Len(); i++ {
+			record := table.Index(i)
+			f.records[record.FieldByName("ID").String()] = record
+			for _, field := range dataFields(record.Type()) {
+				if !contains(ownershipFields, field.name) {
+					continue
+				}
+				if owner, ok := derefValue(record.Field(field.index)).(string); ok && owner != "" {
+					f.children[owner] = append(f.children[owner], record)
+				}
+			}
+		}
+	}
+	return f
+}
+
+// tree fingerprints v and its owned records; cycles are cut at the repeated record
+func (f *fingerprinter) tree(v reflect.Value) string {
+	fp, _ := f.walk(v)
+	return fp
+}
+
+// walk implements tree. It reports false when a cycle was cut below v: the
+// result then depends on where the cycle was entered and is not memoized.
+func (f *fingerprinter) walk(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "", true
+	}
+	id := v.FieldByName("ID").String()
+	if fp, ok := f.done[id]; ok {
+		return fp, true
+	}
+	own := f.own(v)
+	if f.visiting[id] {
+		return own, false
+	}
+	f.visiting[id] = true
+	defer delete(f.visiting, id)
+
+	complete := true
+	var parts []string
+	for _, child := range f.children[id] {
+		part, ok := f.walk(child)
+		parts = append(parts, part)
+		complete = complete && ok
+	}
+	fp := own
+	if len(parts) > 0 {
+		sort.Strings(parts)
+		sum := sha256.Sum256([]byte(own + "\n" + strings.Join(parts, "\n")))
+		fp = hex.EncodeToString(sum[:])
+	}
+	if complete {
+		f.done[id] = fp
+	}
+	return fp, complete
+}
+
+// own fingerprints v with the records it references in place of their IDs
+func (f *fingerprinter) own(v reflect.Value) string {
+	fields := dataFields(v.Type())
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+
+	parts := []string{Fingerprint(v.Interface())}
+	for _, field := range fields {
+		if !contains(referenceFields, field.name) {
+			continue
+		}
+		ref, _ := derefValue(v.Field(field.index)).(string)
+		if ref == "" {
+			continue
+		}
+		if target, ok := f.records[ref]; ok {
+			ref = Fingerprint(target.Interface())
+		}
+		parts = append(parts, field.name+":"+ref)
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package hsds_types
+
+import "testing"
+
+// fingerprintDataset returns two organizations, a service of the first with
+// a phone, and the same service imported under other IDs
+func fingerprintDataset() *Dataset {
+	o1, o2, s1, s2 := testID(1), testID(2), testID(3), testID(4)
+	return &Dataset{
+		Organizations: []Organization{{ID: o1, Name: "Food Bank"}, {ID: o2, Name: "Legal Aid"}},
+		Services: []Service{
+			{ID: s1, OrganizationID: o1, Name: "Pantry", Status: ServiceStatusActive},
+			{ID: s2, OrganizationID: o1, Name: "Pantry", Status: ServiceStatusActive},
+		},
+		Phones: []Phone{
+			{ID: testID(5), ServiceID: &s1, Number: "+12065551212"},
+			{ID: testID(6), ServiceID: &s2, Number: "+12065551212"},
+		},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	ds := fingerprintDataset()
+	a, b := ds.Services[0], ds.Services[1]
+	if Fingerprint(a) != Fingerprint(&b) {
+		t.Error("same data under different IDs has different fingerprints")
+	}
+	b.Name = "Food Pantry"
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Error("renamed service kept its fingerprint")
+	}
+	if Fingerprint(a) == Fingerprint(Organization{ID: a.ID, Name: a.Name}) {
+		t.Error("records of different types share a fingerprint")
+	}
+	if Fingerprint(nil) != "" {
+		t.Error("nil record has a fingerprint")
+	}
+}
+
+func TestDatasetFingerprint(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ds *Dataset)
+		same   bool
+	}{
+		{"unchanged", func(ds *Dataset) {}, true},
+		{"timestamps only", func(ds *Dataset) { ds.Services[0].UpdatedAt = FlexTime{testTime} }, true},
+		{"owned phone changed", func(ds *Dataset) { ds.Phones[0].Number = "+12065550000" }, false},
+		{"moved to another organization", func(ds *Dataset) { ds.Services[0].OrganizationID = testID(2) }, false},
+		{"owning organization renamed", func(ds *Dataset) { ds.Organizations[0].Name = "Food Bank NW" }, false},
+	}
+	for _, tt := range tests {
+		ds := fingerprintDataset()
+		before := DatasetFingerprint(ds, ds.Services[0])
+		tt.change(ds)
+		if after := DatasetFingerprint(ds, ds.Services[0]); (after == before) != tt.same {
+			t.Errorf("%s: fingerprint unchanged = %v, want %v", tt.name, after == before, tt.same)
+		}
+	}
+
+	ds := fingerprintDataset()
+	if DatasetFingerprint(ds, ds.Services[0]) != DatasetFingerprint(ds, ds.Services[1]) {
+		t.Error("the same service tree under different IDs has different fingerprints")
+	}
+}
+
+func TestDatasetFingerprintsCycle(t *testing.T) {
+	// two organizations each naming the other as parent own each other
+	o1, o2 := testID(1), testID(2)
+	ds := &Dataset{Organizations: []Organization{
+		{ID: o1, Name: "Food Bank", ParentOrganizationID: &o2},
+		{ID: o2, Name: "Coalition", ParentOrganizationID: &o1},
+	}}
+	fps := DatasetFingerprints(ds)
+	for _, org := range ds.Organizations {
+		if want := DatasetFingerprint(ds, org); fps[org.ID] != want {
+			t.Errorf("%s: batch fingerprint %s differs from fresh %s", org.Name, fps[org.ID], want)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package hsds_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HSDS serialization layouts for the flexible time types
+const (
+	flexTimeLayout  = time.RFC3339Nano
+	flexDateLayout  = "2006-01-02"
+	flexClockLayout = "15:04:05"
+)
+
+// FlexTime is a timestamp that parses any of TimeFormats from JSON, text or
+// SQL and serializes as RFC 3339. A time of day alone is rejected.
+type FlexTime struct {
+	time.Time
+}
+
+// FlexDate is a calendar date that parses any of TimeFormats, keeping only
+// the date, and serializes as YYYY-MM-DD
+type FlexDate struct {
+	time.Time
+}
+
+// FlexClock is a time of day, such as opens_at, that parses any of
+// TimeFormats, keeping only the clock, and serializes as HH:MM:SS
+type FlexClock struct {
+	time.Time
+}
+
+// NewFlexTime returns a pointer to t as a FlexTime
+func NewFlexTime(t time.Time) *FlexTime {
+	return &FlexTime{t.UTC()}
+}
+
+// NewFlexDate returns a pointer to the date of t
+func NewFlexDate(t time.Time) *FlexDate {
+	return &FlexDate{toDate(t)}
+}
+
+// NewFlexClock returns a pointer to the time of day of t
+func NewFlexClock(t time.Time) *FlexClock {
+	return &FlexClock{toClock(t)}
+}
+
+// TimePtr returns the timestamp as a *time.Time, or nil when t is nil, for
+// callers migrating from the former *time.Time fields
+func (t *FlexTime) TimePtr() *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// TimePtr returns the date as a *time.Time, or nil when d is nil
+func (d *FlexDate) TimePtr() *time.Time {
+	if d == nil {
+		return nil
+	}
+	v := d.Time
+	return &v
+}
+
+// TimePtr returns the time of day as a *time.Time, or nil when c is nil
+func (c *FlexClock) TimePtr() *time.Time {
+	if c == nil {
+		return nil
+	}
+	v := c.Time
+	return &v
+}
+
+// String formats the timestamp as RFC 3339, or "" when zero
+func (t FlexTime) String() string {
+	return formatFlex(t.Time, flexTimeLayout)
+}
+
+// String formats the date as YYYY-MM-DD, or "" when zero
+func (d FlexDate) String() string {
+	return formatFlex(d.Time, flexDateLayout)
+}
+
+// String formats the time of day as HH:MM:SS, or "" when zero
+func (c FlexClock) String() string {
+	return formatFlex(c.Time, flexClockLayout)
+}
+
+// MarshalJSON encodes the timestamp as an RFC 3339 string, or null when zero
+func (t FlexTime) MarshalJSON() ([]byte, error) {
+	return marshalFlex(t.Time, flexTimeLayout)
+}
+
+// MarshalJSON encodes the date as a YYYY-MM-DD string, or null when zero
+func (d FlexDate) MarshalJSON() ([]byte, error) {
+	return marshalFlex(d.Time, flexDateLayout)
+}
+
+// MarshalJSON encodes the time of day as an HH:MM:SS string, or null when zero
+func (c FlexClock) MarshalJSON() ([]byte, error) {
+	return marshalFlex(c.Time, flexClockLayout)
+}
+
+// MarshalText formats the timestamp as RFC 3339, or empty when zero
+func (t FlexTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// MarshalText formats the date as YYYY-MM-DD, or empty when zero
+func (d FlexDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalText formats the time of day as HH:MM:SS, or empty when zero
+func (c FlexClock) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalJSON parses a string in any of TimeFormats; null leaves the value zero
+func (t *FlexTime) UnmarshalJSON(data []byte) error {
+	return unmarshalFlex(data, &t.Time, TimeKindDateTime)
+}
+
+// UnmarshalJSON parses a string in any of TimeFormats; null leaves the value zero
+func (d *FlexDate) UnmarshalJSON(data []byte) error {
+	return unmarshalFlex(data, &d.Time, TimeKindDate)
+}
+
+// UnmarshalJSON parses a string in any of TimeFormats; null leaves the value zero
+func (c *FlexClock) UnmarshalJSON(data []byte) error {
+	return unmarshalFlex(data, &c.Time, TimeKindTimeOfDay)
+}
+
+// UnmarshalText parses text in any of TimeFormats
+func (t *FlexTime) UnmarshalText(text []byte) error {
+	return parseFlex(string(text), &t.Time, TimeKindDateTime)
+}
+
+// UnmarshalText parses text in any of TimeFormats
+func (d *FlexDate) UnmarshalText(text []byte) error {
+	return parseFlex(string(text), &d.Time, TimeKindDate)
+}
+
+// UnmarshalText parses text in any of TimeFormats
+func (c *FlexClock) UnmarshalText(text []byte) error {
+	return parseFlex(string(text), &c.Time, TimeKindTimeOfDay)
+}
+
+// Scan reads a timestamp column, accepting time values and strings
+func (t *FlexTime) Scan(src any) error {
+	return scanFlex(src, &t.Time, TimeKindDateTime)
+}
+
+// Scan reads a date column, accepting time values and strings
+func (d *FlexDate) Scan(src any) error {
+	return scanFlex(src, &d.Time, TimeKindDate)
+}
+
+// Scan reads a time column, accepting time values and strings
+func (c *FlexClock) Scan(src any) error {
+	return scanFlex(src, &c.Time, TimeKindTimeOfDay)
+}
+
+// Value writes the timestamp as a time value, or NULL when zero
+func (t FlexTime) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.Time.UTC(), nil
+}
+
+// Value writes the date as YYYY-MM-DD, or NULL when zero
+func (d FlexDate) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// Value writes the time of day as HH:MM:SS, or NULL when zero
+func (c FlexClock) Value() (driver.Value, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+	return c.String(), nil
+}
+
+// asTime returns the instant held by a time.Time or one of the flexible time types
+func asTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case FlexTime:
+		return t.Time, true
+	case FlexDate:
+		return t.Time, true
+	case FlexClock:
+		return t.Time, true
+	}
+	return time.Time{}, false
+}
+
+// toDate truncates t to midnight UTC of its date
+func toDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// toClock keeps the time of day of t on the zero date
+func toClock(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// formatFlex formats t with layout, or "" when zero
+func formatFlex(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(layout)
+}
+
+// marshalFlex encodes t as a JSON string in layout, or null when zero
+func marshalFlex(t time.Time, layout string) ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(layout))
+}
+
+// unmarshalFlex decodes a JSON string or null into dst
+func unmarshalFlex(data []byte, dst *time.Time, kind TimeKind) error {
+	if string(data) == "null" {
+		*dst = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("time must be a string: %w", err)
+	}
+	return parseFlex(s, dst, kind)
+}
+
+// parseFlex parses s as kind with DefaultTimeParser into dst; an empty string is zero
+func parseFlex(s string, dst *time.Time, kind TimeKind) error {
+	if s == "" {
+		*dst = time.Time{}
+		return nil
+	}
+	t, err := DefaultTimeParser.ParseAs(s, kind)
+	if err != nil {
+		return err
+	}
+	*dst = t
+	return nil
+}
+
+// scanFlex reads a database value into dst
+func scanFlex(src any, dst *time.Time, kind TimeKind) error {
+	switch v := src.(type) {
+	case nil:
+		*dst = time.Time{}
+		return nil
+	case time.Time:
+		*dst = normalizeTime(v.UTC(), kind)
+		return nil
+	case string:
+		return parseFlex(v, dst, kind)
+	case []byte:
+		return parseFlex(string(v), dst, kind)
+	}
+	return fmt.Errorf("cannot scan %T into a time", src)
+}
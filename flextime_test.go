@@ -0,0 +1,177 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFlexTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{`"2024-01-05T10:00:00Z"`, time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC), false},
+		{`"2024-01-05T10:00:00-08:00"`, time.Date(2024, 1, 5, 18, 0, 0, 0, time.UTC), false},
+		{`"2024-01-05 10:00:00"`, time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC), false},
+		{`"2024-01-05 10:00:00.123456+00"`, time.Date(2024, 1, 5, 10, 0, 0, 123456000, time.UTC), false},
+		{`"2024-01-05"`, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), false},
+		{`null`, time.Time{}, false},
+		{`""`, time.Time{}, false},
+		{`"10:00:00"`, time.Time{}, true},
+		{`"soon"`, time.Time{}, true},
+		{`42`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		var got FlexTime
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Time.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got.Time, tt.want)
+		}
+	}
+}
+
+func TestFlexDateAndClockUnmarshalJSON(t *testing.T) {
+	var d FlexDate
+	if err := json.Unmarshal([]byte(`"2024-03-10T23:30:00-08:00"`), &d); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.String(); got != "2024-03-10" {
+		t.Errorf("FlexDate = %q, want the date as written, 2024-03-10", got)
+	}
+
+	var c FlexClock
+	if err := json.Unmarshal([]byte(`"2024-03-10T09:30:15Z"`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.String(); got != "09:30:15" {
+		t.Errorf("FlexClock = %q, want 09:30:15", got)
+	}
+	if err := json.Unmarshal([]byte(`"2024-03-10"`), &c); err == nil {
+		t.Error("FlexClock accepted a date without a time of day")
+	}
+}
+
+func TestFlexMarshal(t *testing.T) {
+	at := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    any
+		wantJSON string
+		wantText string
+	}{
+		{"time", FlexTime{at}, `"2024-03-10T09:30:00Z"`, "2024-03-10T09:30:00Z"},
+		{"date", *NewFlexDate(at), `"2024-03-10"`, "2024-03-10"},
+		{"clock", *NewFlexClock(at), `"09:30:00"`, "09:30:00"},
+		{"zero time", FlexTime{}, `null`, ""},
+		{"zero date", FlexDate{}, `null`, ""},
+		{"zero clock", FlexClock{}, `null`, ""},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(data) != tt.wantJSON {
+			t.Errorf("%s: MarshalJSON = %s, want %s", tt.name, data, tt.wantJSON)
+		}
+		text, err := tt.value.(interface{ MarshalText() ([]byte, error) }).MarshalText()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("%s: MarshalText = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+}
+
+func TestFlexTextRoundTrip(t *testing.T) {
+	at := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	date, clock := NewFlexDate(at), NewFlexClock(at)
+
+	text, _ := date.MarshalText()
+	var d FlexDate
+	if err := d.UnmarshalText(text); err != nil || !d.Equal(date.Time) {
+		t.Errorf("FlexDate text round trip = %v, %v; want %v", d, err, date)
+	}
+	text, _ = clock.MarshalText()
+	var c FlexClock
+	if err := c.UnmarshalText(text); err != nil || !c.Equal(clock.Time) {
+		t.Errorf("FlexClock text round trip = %v, %v; want %v", c, err, clock)
+	}
+}
+
+func TestFlexScanAndValue(t *testing.T) {
+	var d FlexDate
+	if err := d.Scan(time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := d.Value(); v != "2024-03-10" {
+		t.Errorf("FlexDate.Value = %v, want 2024-03-10", v)
+	}
+
+	var c FlexClock
+	if err := c.Scan([]byte("17:45:00")); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := c.Value(); v != "17:45:00" {
+		t.Errorf("FlexClock.Value = %v, want 17:45:00", v)
+	}
+
+	var ft FlexTime
+	if err := ft.Scan(nil); err != nil || !ft.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v; want zero", ft, err)
+	}
+	if v, _ := ft.Value(); v != nil {
+		t.Errorf("zero FlexTime.Value = %v, want nil", v)
+	}
+	if err := ft.Scan(42); err == nil {
+		t.Error("Scan(42) succeeded")
+	}
+}
+
+func TestRecordTimestampsDecodeWithStandardLibrary(t *testing.T) {
+	var org Organization
+	data := `{"id":"x","name":"n","created_at":"2024-01-05 10:00:00","updated_at":"2024-01-06T10:00:00Z"}`
+	if err := json.Unmarshal([]byte(data), &org); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if want := time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC); !org.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", org.CreatedAt, want)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal([]byte(`{"id":"m","last_action_date":"2024-01-05 10:00:00"}`), &meta); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if got := meta.LastActionDate.String(); got != "2024-01-05" {
+		t.Errorf("LastActionDate = %q, want 2024-01-05", got)
+	}
+}
+
+func TestFlexTimePtr(t *testing.T) {
+	var nilTime *FlexTime
+	var nilDate *FlexDate
+	var nilClock *FlexClock
+	if nilTime.TimePtr() != nil || nilDate.TimePtr() != nil || nilClock.TimePtr() != nil {
+		t.Fatal("TimePtr of nil should be nil")
+	}
+
+	ts := NewFlexTime(testTime)
+	p := ts.TimePtr()
+	if p == nil || !p.Equal(testTime) {
+		t.Fatalf("TimePtr = %v, want %v", p, testTime)
+	}
+	*p = p.Add(time.Hour)
+	if !ts.Equal(testTime) {
+		t.Error("TimePtr should return a copy")
+	}
+	if got := NewFlexDate(testTime).TimePtr(); got == nil || got.Hour() != 0 {
+		t.Errorf("FlexDate.TimePtr = %v, want midnight", got)
+	}
+}
@@ -0,0 +1,371 @@
+package hsds_types
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GeocodePrecision describes how closely a geocode matches the real address
+type GeocodePrecision string
+
+// GeocodePrecision values, from most to least precise
+const (
+	GeocodePrecisionRooftop     GeocodePrecision = "rooftop"
+	GeocodePrecisionStreet      GeocodePrecision = "street"
+	GeocodePrecisionZIPCentroid GeocodePrecision = "zip_centroid"
+)
+
+// ErrNoGeocodeMatch is returned by a Geocoder when no candidate matches the address
+var ErrNoGeocodeMatch = errors.New("no geocode match for address")
+
+// GeocodeResult is the coordinate a Geocoder assigned to an address
+type GeocodeResult struct {
+	Latitude  float64
+	Longitude float64
+	Precision GeocodePrecision
+}
+
+// Geocoder resolves an Address to coordinates. Implementations may be local
+// lookup tables or clients for external geocoding services, which should
+// give up when ctx is done.
+type Geocoder interface {
+	Geocode(ctx context.Context, addr *Address) (*GeocodeResult, error)
+}
+
+// LocalGeocoder is an offline Geocoder driven by loadable reference tables.
+// Lookups try address points first, then interpolated street ranges, then
+// ZIP centroids.
+type LocalGeocoder struct {
+	points    map[string]GeocodeResult
+	ranges    map[string][]addressRange
+	centroids map[string]GeocodeResult
+}
+
+// addressRange is one side of a street segment, TIGER-style
+type addressRange struct {
+	fromNumber, toNumber int
+	fromLat, fromLon     float64
+	toLat, toLon         float64
+}
+
+// NewLocalGeocoder creates an empty LocalGeocoder; load data with the Load* methods
+func NewLocalGeocoder() *LocalGeocoder {
+	return &LocalGeocoder{
+		points:    make(map[string]GeocodeResult),
+		ranges:    make(map[string][]addressRange),
+		centroids: make(map[string]GeocodeResult),
+	}
+}
+
+// LoadAddressPoints reads rooftop coordinates from CSV with the header
+// address_1,city,state_province,postal_code,latitude,longitude
+func (g *LocalGeocoder) LoadAddressPoints(r io.Reader) error {
+	return readGeocodeCSV(r, []string{"address_1", "city", "state_province", "postal_code", "latitude", "longitude"}, func(row map[string]string) error {
+		lat, lon, err := parseLatLon(row["latitude"], row["longitude"])
+		if err != nil {
+			return err
+		}
+		addr := &Address{Address1: row["address_1"], City: row["city"], StateProvince: row["state_province"], PostalCode: row["postal_code"], Country: "US"}
+		addr.Normalize()
+		g.points[pointKey(addr)] = GeocodeResult{Latitude: lat, Longitude: lon, Precision: GeocodePrecisionRooftop}
+		return nil
+	})
+}
+
+// LoadAddressRanges reads TIGER-derived street segments from CSV with the header
+// street,postal_code,from_number,to_number,from_latitude,from_longitude,to_latitude,to_longitude
+func (g *LocalGeocoder) LoadAddressRanges(r io.Reader) error {
+	return readGeocodeCSV(r, []string{"street", "postal_code", "from_number", "to_number", "from_latitude", "from_longitude", "to_latitude", "to_longitude"}, func(row map[string]string) error {
+		from, err := strconv.Atoi(row["from_number"])
+		if err != nil {
+			return fmt.Errorf("invalid from_number %q: %w", row["from_number"], err)
+		}
+		to, err := strconv.Atoi(row["to_number"])
+		if err != nil {
+			return fmt.Errorf("invalid to_number %q: %w", row["to_number"], err)
+		}
+		fromLat, fromLon, err := parseLatLon(row["from_latitude"], row["from_longitude"])
+		if err != nil {
+			return err
+		}
+		toLat, toLon, err := parseLatLon(row["to_latitude"], row["to_longitude"])
+		if err != nil {
+			return err
+		}
+		key := rangeKey(normalizeUSStreet(row["street"]), row["postal_code"])
+		g.ranges[key] = append(g.ranges[key], addressRange{
+			fromNumber: from, toNumber: to,
+			fromLat: fromLat, fromLon: fromLon,
+			toLat: toLat, toLon: toLon,
+		})
+		return nil
+	})
+}
+
+// LoadZIPCentroids reads ZIP code centroids from CSV with the header
+// postal_code,latitude,longitude
+func (g *LocalGeocoder) LoadZIPCentroids(r io.Reader) error {
+	return readGeocodeCSV(r, []string{"postal_code", "latitude", "longitude"}, func(row map[string]string) error {
+		lat, lon, err := parseLatLon(row["latitude"], row["longitude"])
+		if err != nil {
+			return err
+		}
+		g.centroids[zip5(row["postal_code"])] = GeocodeResult{Latitude: lat, Longitude: lon, Precision: GeocodePrecisionZIPCentroid}
+		return nil
+	})
+}
+
+// Geocode resolves addr using the most precise table that has a match
+func (g *LocalGeocoder) Geocode(ctx context.Context, addr *Address) (*GeocodeResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if addr == nil {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	normalized := *addr
+	normalized.Normalize()
+
+	if result, ok := g.points[pointKey(&normalized)]; ok {
+		return &result, nil
+	}
+
+	if number, street, ok := splitHouseNumber(normalized.Address1); ok {
+		for _, rng := range g.ranges[rangeKey(street, normalized.PostalCode)] {
+			if result, ok := rng.interpolate(number); ok {
+				return result, nil
+			}
+		}
+	}
+
+	if result, ok := g.centroids[zip5(normalized.PostalCode)]; ok {
+		return &result, nil
+	}
+
+	return nil, ErrNoGeocodeMatch
+}
+
+// interpolate places number proportionally along the segment when it falls
+// inside the range and shares its parity
+func (r addressRange) interpolate(number int) (*GeocodeResult, bool) {
+	low, high := r.fromNumber, r.toNumber
+	if low > high {
+		low, high = high, low
+	}
+	if number < low || number > high {
+		return nil, false
+	}
+	if low%2 == high%2 && number%2 != low%2 {
+		return nil, false
+	}
+
+	fraction := 0.5
+	if r.toNumber != r.fromNumber {
+		fraction = float64(number-r.fromNumber) / float64(r.toNumber-r.fromNumber)
+	}
+
+	return &GeocodeResult{
+		Latitude:  r.fromLat + (r.toLat-r.fromLat)*fraction,
+		Longitude: r.fromLon + (r.toLon-r.fromLon)*fraction,
+		Precision: GeocodePrecisionStreet,
+	}, true
+}
+
+// GeocodeOptions contains optional settings for GeocodeDataset
+type GeocodeOptions struct {
+	// Overwrite re-geocodes locations that already have coordinates
+	Overwrite bool
+}
+
+// GeocodeOutcome records what happened to one location during GeocodeDataset
+type GeocodeOutcome struct {
+	LocationID string
+	AddressID  string
+	Result     *GeocodeResult
+	Err        error
+}
+
+// GeocodeDataset fills Latitude/Longitude on every Location that lacks them
+// using its linked physical Address, preferring physical over other address
+// types. One outcome is returned per location that was attempted. When ctx is
+// done it stops and returns the outcomes so far with ctx.Err().
+func GeocodeDataset(ctx context.Context, ds *Dataset, geocoder Geocoder, opts *GeocodeOptions) ([]GeocodeOutcome, error) {
+	if ds == nil {
+		return nil, fmt.Errorf("dataset is required")
+	}
+	if geocoder == nil {
+		return nil, fmt.Errorf("geocoder is required")
+	}
+	if opts == nil {
+		opts = &GeocodeOptions{}
+	}
+
+	var outcomes []GeocodeOutcome
+	for i := range ds.Locations {
+		if err := ctx.Err(); err != nil {
+			return outcomes, err
+		}
+		loc := &ds.Locations[i]
+		if loc.Latitude != nil && loc.Longitude != nil && !opts.Overwrite {
+			continue
+		}
+
+		addrs := ds.AddressesFor(loc.ID)
+		if len(addrs) == 0 {
+			continue
+		}
+		addr := addrs[0]
+		for _, candidate := range addrs {
+			if candidate.AddressType == LocationTypePhysical {
+				addr = candidate
+				break
+			}
+		}
+
+		outcome := GeocodeOutcome{LocationID: loc.ID, AddressID: addr.ID}
+		result, err := geocoder.Geocode(ctx, &addr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return outcomes, ctx.Err()
+			}
+			outcome.Err = err
+		} else {
+			lat, lon := result.Latitude, result.Longitude
+			loc.Latitude = &lat
+			loc.Longitude = &lon
+			outcome.Result = result
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
+
+// readGeocodeCSV reads a headed CSV file, passing each row keyed by column name
+func readGeocodeCSV(r io.Reader, required []string, fn func(row map[string]string) error) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading line %d: %w", line, err)
+		}
+		row := make(map[string]string, len(required))
+		for _, name := range required {
+			row[name] = strings.TrimSpace(record[columns[name]])
+		}
+		if err := fn(row); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// parseLatLon parses and range-checks a coordinate pair
+func parseLatLon(latStr, lonStr string) (float64, float64, error) {
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, fmt.Errorf("invalid latitude %q", latStr)
+	}
+	lon, err := strconv.ParseFloat(lonStr, 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, fmt.Errorf("invalid longitude %q", lonStr)
+	}
+	return lat, lon, nil
+}
+
+// splitHouseNumber separates the leading house number from a normalized street line
+func splitHouseNumber(line string) (int, string, bool) {
+	number, street, found := strings.Cut(line, " ")
+	if !found {
+		return 0, "", false
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, "", false
+	}
+	tokens := strings.Fields(street)
+	for i := 1; i < len(tokens); i++ {
+		if _, ok := unitDesignators[tokens[i]]; ok || strings.HasPrefix(tokens[i], "#") {
+			tokens = tokens[:i]
+			break
+		}
+	}
+	return n, strings.Join(tokens, " "), true
+}
+
+// pointKey builds the lookup key for a normalized address
+func pointKey(addr *Address) string {
+	return strings.Join([]string{addr.Address1, addr.City, addr.StateProvince, zip5(addr.PostalCode)}, "|")
+}
+
+// rangeKey builds the lookup key for a street segment
+func rangeKey(street, postalCode string) string {
+	return street + "|" + zip5(postalCode)
+}
+
+// zip5 returns the five-digit portion of a ZIP or ZIP+4 code
+func zip5(postalCode string) string {
+	postalCode = strings.TrimSpace(postalCode)
+	if len(postalCode) > 5 {
+		return postalCode[:5]
+	}
+	return postalCode
+}
+
+// geohashAlphabet is the base32 alphabet used by geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash encodes a coordinate as a geohash string of the given length
+func Geohash(lat, lon float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	hash := make([]byte, 0, precision)
+	bit, ch, even := 0, 0, true
+	for len(hash) < precision {
+		rng, value := &latRange, lat
+		if even {
+			rng, value = &lonRange, lon
+		}
+		mid := (rng[0] + rng[1]) / 2
+		ch <<= 1
+		if value >= mid {
+			ch |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return string(hash)
+}
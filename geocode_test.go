@@ -0,0 +1,96 @@
+package hsds_types
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testGeocoder(t *testing.T) *LocalGeocoder {
+	t.Helper()
+	g := NewLocalGeocoder()
+	if err := g.LoadAddressPoints(strings.NewReader("address_1,city,state_province,postal_code,latitude,longitude\n123 Main Street,Seattle,WA,98101,47.6,-122.3\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.LoadAddressRanges(strings.NewReader("street,postal_code,from_number,to_number,from_latitude,from_longitude,to_latitude,to_longitude\nPine St,98101,100,200,47.0,-122.0,48.0,-123.0\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.LoadZIPCentroids(strings.NewReader("postal_code,latitude,longitude\n98101,47.61,-122.33\n")); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestLocalGeocoder(t *testing.T) {
+	g := testGeocoder(t)
+	tests := []struct {
+		address1, postal string
+		precision        GeocodePrecision
+		lat              float64
+		wantErr          error
+	}{
+		{"123 Main St.", "98101-1234", GeocodePrecisionRooftop, 47.6, nil},
+		{"150 Pine Street", "98101", GeocodePrecisionStreet, 47.5, nil},
+		{"151 Pine Street", "98101", GeocodePrecisionZIPCentroid, 47.61, nil},
+		{"1 Unknown Rd", "98101", GeocodePrecisionZIPCentroid, 47.61, nil},
+		{"1 Unknown Rd", "10001", "", 0, ErrNoGeocodeMatch},
+	}
+	for _, tt := range tests {
+		addr := &Address{Address1: tt.address1, City: "Seattle", StateProvince: "WA", PostalCode: tt.postal, Country: "US"}
+		got, err := g.Geocode(context.Background(), addr)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Geocode(%q) error = %v, want %v", tt.address1, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.Precision != tt.precision || got.Latitude < tt.lat-1e-9 || got.Latitude > tt.lat+1e-9 {
+			t.Errorf("Geocode(%q) = %+v, want %s at %v", tt.address1, got, tt.precision, tt.lat)
+		}
+	}
+}
+
+// cancelingGeocoder cancels its context after the first lookup
+type cancelingGeocoder struct {
+	Geocoder
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (g *cancelingGeocoder) Geocode(ctx context.Context, addr *Address) (*GeocodeResult, error) {
+	g.calls++
+	defer g.cancel()
+	return g.Geocoder.Geocode(ctx, addr)
+}
+
+func TestGeocodeDatasetStopsWhenCanceled(t *testing.T) {
+	l1, l2 := "l1", "l2"
+	ds := &Dataset{
+		Locations: []Location{{ID: l1}, {ID: l2}},
+		Addresses: []Address{
+			{ID: "a1", LocationID: &l1, Address1: "123 Main St", City: "Seattle", StateProvince: "WA", PostalCode: "98101", Country: "US"},
+			{ID: "a2", LocationID: &l2, Address1: "150 Pine St", City: "Seattle", StateProvince: "WA", PostalCode: "98101", Country: "US"},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g := &cancelingGeocoder{Geocoder: testGeocoder(t), cancel: cancel}
+	outcomes, err := GeocodeDataset(ctx, ds, g, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if g.calls != 1 || len(outcomes) != 1 || outcomes[0].LocationID != l1 {
+		t.Errorf("calls = %d, outcomes = %+v; want only l1 geocoded", g.calls, outcomes)
+	}
+	if ds.Locations[0].Latitude == nil || ds.Locations[1].Latitude != nil {
+		t.Error("want only the first location to have coordinates")
+	}
+
+	outcomes, err = GeocodeDataset(context.Background(), ds, testGeocoder(t), nil)
+	if err != nil || len(outcomes) != 1 || outcomes[0].LocationID != l2 {
+		t.Errorf("resumed outcomes = %+v, %v; want l2 only", outcomes, err)
+	}
+}
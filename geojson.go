@@ -0,0 +1,164 @@
+package hsds_types
+
+import "fmt"
+
+// FeatureCollection is a GeoJSON (RFC 7946) FeatureCollection of HSDS locations
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON Feature describing a single Location
+type Feature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   PointGeometry     `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// PointGeometry is a GeoJSON Point; coordinates are [longitude, latitude]
+type PointGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// FeatureProperties contains the HSDS data attached to a location feature
+type FeatureProperties struct {
+	Name             *string          `json:"name,omitempty"`
+	OrganizationID   *string          `json:"organization_id,omitempty"`
+	OrganizationName *string          `json:"organization_name,omitempty"`
+	LocationType     string           `json:"location_type"`
+	Address          *string          `json:"address,omitempty"`
+	Services         []FeatureService `json:"services"`
+	Phones           []FeaturePhone   `json:"phones"`
+	Schedule         []string         `json:"schedule"`
+}
+
+// FeatureService is a service offered at a location feature
+type FeatureService struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Status ServiceStatusEnum `json:"status"`
+}
+
+// FeaturePhone is a phone number reachable at a location feature
+type FeaturePhone struct {
+	Number    string          `json:"number"`
+	Extension *PhoneExtension `json:"extension,omitempty"`
+	Type      *string         `json:"type,omitempty"`
+}
+
+// GeoJSONOptions contains optional settings for ToGeoJSON
+type GeoJSONOptions struct {
+	// IncludeInactiveServices keeps services whose status is not active
+	IncludeInactiveServices bool
+	// SkipEmptyLocations drops locations that have no attached services
+	SkipEmptyLocations bool
+}
+
+// ToGeoJSON converts every Location with coordinates into a Point feature,
+// attaching its organization, services, phones and a humanized schedule.
+// Locations with out of range coordinates are skipped and reported in the
+// returned warnings.
+func ToGeoJSON(ds *Dataset, opts *GeoJSONOptions) (*FeatureCollection, []string, error) {
+	if ds == nil {
+		return nil, nil, fmt.Errorf("dataset is required")
+	}
+	if opts == nil {
+		opts = &GeoJSONOptions{}
+	}
+
+	fc := &FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []Feature{},
+	}
+	var warnings []string
+
+	for i := range ds.Locations {
+		loc := &ds.Locations[i]
+		if loc.Latitude == nil || loc.Longitude == nil {
+			continue
+		}
+		if *loc.Latitude < -90 || *loc.Latitude > 90 || *loc.Longitude < -180 || *loc.Longitude > 180 {
+			warnings = append(warnings, fmt.Sprintf("location %s skipped: out of range coordinates (%f, %f)", loc.ID, *loc.Latitude, *loc.Longitude))
+			continue
+		}
+
+		props := FeatureProperties{
+			Name:           loc.Name,
+			OrganizationID: loc.OrganizationID,
+			LocationType:   string(loc.LocationType),
+			Services:       []FeatureService{},
+			Phones:         []FeaturePhone{},
+			Schedule:       []string{},
+		}
+
+		if loc.OrganizationID != nil {
+			if org := ds.OrganizationByID(*loc.OrganizationID); org != nil {
+				props.OrganizationName = &org.Name
+			}
+		}
+
+		if addrs := ds.AddressesFor(loc.ID); len(addrs) > 0 {
+			line := addrs[0].SingleLine()
+			props.Address = &line
+		}
+
+		salIDs := make(map[string]bool)
+		serviceIDs := make(map[string]bool)
+		for _, sal := range ds.ServiceAtLocationsFor(loc.ID) {
+			salIDs[sal.ID] = true
+			svc := ds.ServiceByID(sal.ServiceID)
+			if svc == nil {
+				continue
+			}
+			if svc.Status != ServiceStatusActive && !opts.IncludeInactiveServices {
+				continue
+			}
+			serviceIDs[svc.ID] = true
+			props.Services = append(props.Services, FeatureService{
+				ID:     svc.ID,
+				Name:   svc.Name,
+				Status: svc.Status,
+			})
+		}
+
+		if opts.SkipEmptyLocations && len(props.Services) == 0 {
+			continue
+		}
+
+		// Phones of a listed service count unless they belong to another
+		// location or service at location
+		for _, phone := range ds.Phones {
+			serviceOnly := phone.LocationID == nil && phone.ServiceAtLocationID == nil && phone.ServiceID != nil && serviceIDs[*phone.ServiceID]
+			if matchesID(phone.LocationID, loc.ID) || (phone.ServiceAtLocationID != nil && salIDs[*phone.ServiceAtLocationID]) || serviceOnly {
+				props.Phones = append(props.Phones, FeaturePhone{
+					Number:    phone.Number,
+					Extension: phone.Extension,
+					Type:      phone.Type,
+				})
+			}
+		}
+
+		for i := range ds.Schedules {
+			sched := &ds.Schedules[i]
+			if matchesID(sched.LocationID, loc.ID) || (sched.ServiceAtLocationID != nil && salIDs[*sched.ServiceAtLocationID]) {
+				if text := sched.Humanize(); text != "" {
+					props.Schedule = append(props.Schedule, text)
+				}
+			}
+		}
+
+		fc.Features = append(fc.Features, Feature{
+			Type: "Feature",
+			ID:   loc.ID,
+			Geometry: PointGeometry{
+				Type:        "Point",
+				Coordinates: [2]float64{*loc.Longitude, *loc.Latitude},
+			},
+			Properties: props,
+		})
+	}
+
+	return fc, warnings, nil
+}
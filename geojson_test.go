@@ -0,0 +1,70 @@
+package hsds_types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToGeoJSON(t *testing.T) {
+	lat, lon, badLat := 47.6, -122.3, 95.0
+	l1, l2, l3, s1, s2 := "l1", "l2", "l3", "s1", "s2"
+	ds := &Dataset{
+		Organizations: []Organization{{ID: "o1", Name: "Food Bank"}},
+		Locations: []Location{
+			{ID: l1, OrganizationID: strPtr("o1"), Latitude: &lat, Longitude: &lon, LocationType: LocationTypePhysical},
+			{ID: l2, Latitude: &badLat, Longitude: &lon},
+			{ID: l3},
+		},
+		Services: []Service{
+			{ID: s1, OrganizationID: "o1", Name: "Pantry", Status: ServiceStatusActive},
+			{ID: s2, OrganizationID: "o1", Name: "Closed Program", Status: ServiceStatusInactive},
+		},
+		ServiceAtLocations: []ServiceAtLocation{
+			{ID: "sal1", ServiceID: s1, LocationID: l1},
+			{ID: "sal2", ServiceID: s2, LocationID: l1},
+		},
+		Phones: []Phone{
+			{ID: "p1", LocationID: &l1, Number: "+12065550001"},
+			{ID: "p2", ServiceID: &s1, Number: "+12065550002"},
+			{ID: "p3", ServiceID: &s1, LocationID: &l3, Number: "+12065550003"},
+			{ID: "p4", ServiceID: &s2, Number: "+12065550004"},
+		},
+	}
+
+	fc, warnings, err := ToGeoJSON(ds, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "location l2") {
+		t.Errorf("warnings = %q, want one for l2", warnings)
+	}
+	if len(fc.Features) != 1 {
+		t.Fatalf("features = %d, want only l1", len(fc.Features))
+	}
+
+	f := fc.Features[0]
+	if f.ID != l1 || f.Geometry.Coordinates != [2]float64{lon, lat} {
+		t.Errorf("feature = %s at %v", f.ID, f.Geometry.Coordinates)
+	}
+	if f.Properties.OrganizationName == nil || *f.Properties.OrganizationName != "Food Bank" {
+		t.Errorf("organization name = %v", f.Properties.OrganizationName)
+	}
+	if len(f.Properties.Services) != 1 || f.Properties.Services[0].ID != s1 {
+		t.Errorf("services = %+v, want only the active s1", f.Properties.Services)
+	}
+	var numbers []string
+	for _, p := range f.Properties.Phones {
+		numbers = append(numbers, p.Number)
+	}
+	if strings.Join(numbers, ",") != "+12065550001,+12065550002" {
+		t.Errorf("phones = %v, want the location phone and the service-only phone", numbers)
+	}
+
+	fc, _, err = ToGeoJSON(ds, &GeoJSONOptions{IncludeInactiveServices: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fc.Features[0].Properties; len(got.Services) != 2 || len(got.Phones) != 3 {
+		t.Errorf("with inactive services: %d services, %d phones; want 2 and 3", len(got.Services), len(got.Phones))
+	}
+}
@@ -0,0 +1,272 @@
+package hsds_types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ServiceGraph builds an organization together with its services, locations
+// and their child records, wiring every foreign key:
+//
+//	ds, err := NewServiceGraph(org).
+//		AddService("Food Pantry", ServiceStatusActive, nil).
+//		AddLocation(LocationTypePhysical, nil).
+//		WithAddress("1 Main St", "Seattle", "WA", "98101", "US", LocationTypePhysical, nil).
+//		WithPhone("206-555-0100", nil).
+//		WithSchedule(&ScheduleOptions{Freq: &weekly, Byday: &weekdays}).
+//		Build()
+//
+// With* calls attach to the most recently added service or location, or to
+// the organization before either is added. Errors are collected as the graph
+// is built and returned together by Build.
+type ServiceGraph struct {
+	factory *Factory
+	ds      Dataset
+	steps   int
+	errs    []error
+
+	orgID      string
+	serviceID  string
+	locationID string
+	// cursor is the entity With* calls attach to
+	cursor   LinkEntityEnum
+	cursorID string
+}
+
+// NewServiceGraph starts a graph for org using the default Factory
+func NewServiceGraph(org *Organization) *ServiceGraph {
+	return defaultFactory.NewServiceGraph(org)
+}
+
+// NewServiceGraph starts a graph for org whose records are created by the factory
+func (f *Factory) NewServiceGraph(org *Organization) *ServiceGraph {
+	g := &ServiceGraph{factory: f}
+	if org == nil {
+		g.errs = append(g.errs, fmt.Errorf("organization is required"))
+		return g
+	}
+	g.ds.Organizations = append(g.ds.Organizations, *org)
+	g.orgID = org.ID
+	g.cursor, g.cursorID = LinkEntityOrganization, org.ID
+	return g
+}
+
+// AddService adds a service of the organization and makes it current
+func (g *ServiceGraph) AddService(name string, status ServiceStatusEnum, opts *ServiceOptions) *ServiceGraph {
+	g.steps++
+	if g.orgID == "" {
+		return g
+	}
+	svc, err := g.factory.NewService(g.orgID, name, status, opts)
+	if err != nil {
+		return g.fail("AddService", err)
+	}
+	g.ds.Services = append(g.ds.Services, *svc)
+	g.serviceID, g.locationID = svc.ID, ""
+	g.cursor, g.cursorID = LinkEntityService, svc.ID
+	return g
+}
+
+// AddLocation adds a location of the organization, links it to the current
+// service if there is one, and makes it current
+func (g *ServiceGraph) AddLocation(locationType LocationLocationTypeEnum, opts *LocationOptions) *ServiceGraph {
+	g.steps++
+	if g.orgID == "" {
+		return g
+	}
+	o := LocationOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.OrganizationID = &g.orgID
+
+	loc, err := g.factory.NewLocation(locationType, &o)
+	if err != nil {
+		return g.fail("AddLocation", err)
+	}
+	g.ds.Locations = append(g.ds.Locations, *loc)
+	g.locationID = loc.ID
+	g.cursor, g.cursorID = LinkEntityLocation, loc.ID
+
+	if g.serviceID != "" {
+		sal, err := g.factory.NewServiceAtLocation(g.serviceID, loc.ID, nil)
+		if err != nil {
+			return g.fail("AddLocation", err)
+		}
+		g.ds.ServiceAtLocations = append(g.ds.ServiceAtLocations, *sal)
+	}
+	return g
+}
+
+// WithAddress adds an address to the current location
+func (g *ServiceGraph) WithAddress(
+	address1, city, stateProvince, postalCode, country string,
+	addressType LocationLocationTypeEnum,
+	opts *AddressOptions,
+) *ServiceGraph {
+	g.steps++
+	if g.locationID == "" {
+		return g.fail("WithAddress", fmt.Errorf("an address must follow AddLocation"))
+	}
+	o := AddressOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.LocationID = &g.locationID
+
+	addr, err := g.factory.NewAddress(address1, city, stateProvince, postalCode, country, addressType, &o)
+	if err != nil {
+		return g.fail("WithAddress", err)
+	}
+	g.ds.Addresses = append(g.ds.Addresses, *addr)
+	return g
+}
+
+// WithPhone adds a phone to the current location, service or organization
+func (g *ServiceGraph) WithPhone(number string, opts *PhoneOptions) *ServiceGraph {
+	g.steps++
+	if g.cursorID == "" {
+		return g
+	}
+	o := PhoneOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	id := g.cursorID
+	switch g.cursor {
+	case LinkEntityLocation:
+		o.LocationID = &id
+	case LinkEntityService:
+		o.ServiceID = &id
+	default:
+		o.OrganizationID = &id
+	}
+
+	phone, err := g.factory.NewPhone(number, &o)
+	if err != nil {
+		return g.fail("WithPhone", err)
+	}
+	g.ds.Phones = append(g.ds.Phones, *phone)
+	return g
+}
+
+// WithSchedule adds a schedule to the current location or service
+func (g *ServiceGraph) WithSchedule(opts *ScheduleOptions) *ServiceGraph {
+	g.steps++
+	o := ScheduleOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	id := g.cursorID
+	switch g.cursor {
+	case LinkEntityLocation:
+		o.LocationID = &id
+	case LinkEntityService:
+		o.ServiceID = &id
+	default:
+		return g.fail("WithSchedule", fmt.Errorf("a schedule must follow AddService or AddLocation"))
+	}
+
+	schedule, err := g.factory.NewSchedule(&o)
+	if err != nil {
+		return g.fail("WithSchedule", err)
+	}
+	g.ds.Schedules = append(g.ds.Schedules, *schedule)
+	return g
+}
+
+// WithAttribute tags the current location, service or organization with a taxonomy term
+func (g *ServiceGraph) WithAttribute(taxonomyTermID string, opts *AttributeOptions) *ServiceGraph {
+	g.steps++
+	if g.cursorID == "" {
+		return g
+	}
+	attr, err := g.factory.NewAttribute(taxonomyTermID, g.cursorID, string(g.cursor), opts)
+	if err != nil {
+		return g.fail("WithAttribute", err)
+	}
+	g.ds.Attributes = append(g.ds.Attributes, *attr)
+	return g
+}
+
+// graphReferences maps the foreign keys Build checks to the table they
+// reference. Taxonomy terms, programs and contacts are not created by the
+// graph and parent organizations are usually stored separately, so those
+// references are not required to resolve.
+var graphReferences = map[string]MetadataResourceTypeEnum{
+	"organization_id":        ResourceTypeOrganization,
+	"service_id":             ResourceTypeService,
+	"location_id":            ResourceTypeLocation,
+	"service_at_location_id": ResourceTypeServiceAtLocation,
+}
+
+// Build validates the whole graph and returns its records, or every error met
+// while building it. Every ID must be unique and every organization, service,
+// location, service at location and attribute link must resolve inside the graph.
+// The dataset's tables are copies, so adding to the graph afterwards does not
+// change it.
+func (g *ServiceGraph) Build() (*Dataset, error) {
+	if len(g.errs) > 0 {
+		return nil, errors.Join(g.errs...)
+	}
+	ds := g.ds.copyTables()
+	if err := validateGraph(&ds); err != nil {
+		return nil, err
+	}
+	return &ds, nil
+}
+
+// validateGraph checks that the IDs of ds are unique and that its references
+// resolve within it
+func validateGraph(ds *Dataset) error {
+	var errs []error
+	ids := make(map[MetadataResourceTypeEnum]map[string]bool)
+	owners := make(map[string]MetadataResourceTypeEnum)
+	v := reflect.ValueOf(ds).Elem()
+	for _, table := range tableOrder {
+		rows := v.Field(datasetTables[table])
+		ids[table] = make(map[string]bool, rows.Len())
+		for i := 0; i < rows.Len(); i++ {
+			id := rows.Index(i).FieldByName("ID").String()
+			if owner, ok := owners[id]; ok {
+				errs = append(errs, fmt.Errorf("%s %s: ID already used by a %s", table, id, owner))
+				continue
+			}
+			owners[id] = table
+			ids[table][id] = true
+		}
+	}
+
+	for _, table := range tableOrder {
+		rows := v.Field(datasetTables[table])
+		for i := 0; i < rows.Len(); i++ {
+			record := rows.Index(i)
+			id := record.FieldByName("ID").String()
+			for _, field := range dataFields(record.Type()) {
+				target, ok := graphReferences[field.name]
+				if !ok {
+					continue
+				}
+				ref, set := derefValue(record.Field(field.index)).(string)
+				if set && ref != "" && !ids[target][ref] {
+					errs = append(errs, fmt.Errorf("%s %s: %s %s is not in the graph", table, id, field.name, ref))
+				}
+			}
+		}
+	}
+
+	for _, attr := range ds.Attributes {
+		target := MetadataResourceTypeEnum(attr.LinkEntity)
+		if _, known := ids[target]; !known || !ids[target][attr.LinkID] {
+			errs = append(errs, fmt.Errorf("attribute %s: linked %s %s is not in the graph", attr.ID, attr.LinkEntity, attr.LinkID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fail records an error against the current step
+func (g *ServiceGraph) fail(step string, err error) *ServiceGraph {
+	g.errs = append(g.errs, fmt.Errorf("step %d (%s): %w", g.steps, step, err))
+	return g
+}
@@ -0,0 +1,105 @@
+package hsds_types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceGraphBuild(t *testing.T) {
+	f := newTestFactory()
+	org, err := f.NewOrganization("Food Bank", "Groceries", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekly := ScheduleFreqWeekly
+	ds, err := f.NewServiceGraph(org).
+		AddService("Food Pantry", ServiceStatusActive, nil).
+		AddLocation(LocationTypePhysical, nil).
+		WithAddress("1 Main St", "Seattle", "WA", "98101", "US", LocationTypePhysical, nil).
+		WithPhone("206-555-0100", nil).
+		WithSchedule(&ScheduleOptions{Freq: &weekly}).
+		WithAttribute("00000000-0000-4000-8000-0000000000ff", nil).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	sal := ds.ServiceAtLocations
+	if len(sal) != 1 || sal[0].ServiceID != ds.Services[0].ID || sal[0].LocationID != ds.Locations[0].ID {
+		t.Errorf("service at location = %+v, want the service linked to the location", sal)
+	}
+	if *ds.Phones[0].LocationID != ds.Locations[0].ID || *ds.Addresses[0].LocationID != ds.Locations[0].ID {
+		t.Error("phone and address are not attached to the location")
+	}
+	if ds.Attributes[0].LinkID != ds.Locations[0].ID || ds.Attributes[0].LinkEntity != "location" {
+		t.Errorf("attribute links %s %s, want the location", ds.Attributes[0].LinkEntity, ds.Attributes[0].LinkID)
+	}
+}
+
+func TestServiceGraphBuildCopiesTables(t *testing.T) {
+	f := newTestFactory()
+	org, err := f.NewOrganization("Food Bank", "Groceries", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := f.NewServiceGraph(org).AddService("Food Pantry", ServiceStatusActive, nil)
+	first, err := g.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Services[0].Name = "Edited"
+
+	second, err := g.AddService("Clinic", ServiceStatusActive, nil).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Services[0].Name != "Food Pantry" || len(first.Services) != 1 {
+		t.Errorf("builds share tables: first %+v, second %+v", first.Services, second.Services)
+	}
+}
+
+func TestServiceGraphBuildValidatesGraph(t *testing.T) {
+	outside := "00000000-0000-4000-8000-0000000000ee"
+	tests := []struct {
+		name    string
+		factory *Factory
+		build   func(g *ServiceGraph) *ServiceGraph
+		wantErr string
+	}{
+		{
+			name:    "duplicate IDs",
+			factory: NewFactory(FixedClock{testTime}, fixedIDs("00000000-0000-4000-8000-000000000001")),
+			build: func(g *ServiceGraph) *ServiceGraph {
+				return g.AddService("Food Pantry", ServiceStatusActive, nil)
+			},
+			wantErr: "ID already used by a organization",
+		},
+		{
+			name:    "dangling service at location",
+			factory: newTestFactory(),
+			build: func(g *ServiceGraph) *ServiceGraph {
+				return g.AddService("Food Pantry", ServiceStatusActive, nil).
+					WithPhone("206-555-0100", &PhoneOptions{ServiceAtLocationID: &outside})
+			},
+			wantErr: "service_at_location_id " + outside + " is not in the graph",
+		},
+		{
+			name:    "step error",
+			factory: newTestFactory(),
+			build: func(g *ServiceGraph) *ServiceGraph {
+				return g.WithAddress("1 Main St", "Seattle", "WA", "98101", "US", LocationTypePhysical, nil)
+			},
+			wantErr: "step 1 (WithAddress)",
+		},
+	}
+	for _, tt := range tests {
+		org, err := tt.factory.NewOrganization("Food Bank", "Groceries", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tt.build(tt.factory.NewServiceGraph(org)).Build()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: Build error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
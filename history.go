@@ -0,0 +1,169 @@
+package hsds_types
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ErrNotCreatedYet is returned by AsOf when the record was created after the requested time
+var ErrNotCreatedYet = errors.New("record did not exist at the requested time")
+
+// AsOf reconstructs what entity looked like at time t by undoing, newest
+// first, every change in history whose CreatedAt is after t. Only rows whose
+// ResourceID matches the entity's ID are applied; entity is not modified.
+func AsOf[T any](entity *T, history []Metadata, t time.Time) (*T, error) {
+	if entity == nil {
+		return nil, fmt.Errorf("entity is required")
+	}
+
+	snapshot := new(T)
+	*snapshot = *entity
+	v := reflect.ValueOf(snapshot).Elem()
+	if v.Kind() != reflect.Struct || !v.FieldByName("ID").IsValid() {
+		return nil, fmt.Errorf("%T is not an HSDS record", entity)
+	}
+	id := v.FieldByName("ID").String()
+
+	fields := make(map[string]int)
+	for _, field := range dataFields(v.Type()) {
+		fields[field.name] = field.index
+	}
+
+	rows := historyFor(history, id)
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if !row.CreatedAt.After(t) {
+			break
+		}
+		if row.LastActionType == ActionTypeCreate {
+			return nil, ErrNotCreatedYet
+		}
+		index, ok := fields[row.FieldName]
+		if !ok {
+			continue
+		}
+		if err := setFieldFromString(v.Field(index), row.PreviousValue); err != nil {
+			return nil, fmt.Errorf("restoring %s from metadata %s: %w", row.FieldName, row.ID, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// TimelineEntry groups the field changes made to a resource in one call
+type TimelineEntry struct {
+	At         time.Time                  `json:"at"`
+	CallID     string                     `json:"call_id"`
+	ActionType MetadataLastActionTypeEnum `json:"action_type"`
+	UpdatedBy  string                     `json:"updated_by"`
+	Changes    []FieldChange              `json:"changes"`
+}
+
+// Timeline returns the changes recorded for a resource, oldest first, with
+// rows sharing a CallID grouped into one entry
+func Timeline(history []Metadata, resourceID string) []TimelineEntry {
+	var entries []TimelineEntry
+	byCall := make(map[string]int)
+
+	for _, row := range historyFor(history, resourceID) {
+		i, ok := byCall[row.CallID]
+		if !ok || row.CallID == "" {
+			entries = append(entries, TimelineEntry{
+				At:         row.CreatedAt.Time,
+				CallID:     row.CallID,
+				ActionType: row.LastActionType,
+				UpdatedBy:  row.UpdatedBy,
+			})
+			i = len(entries) - 1
+			byCall[row.CallID] = i
+		}
+		entries[i].Changes = append(entries[i].Changes, FieldChange{
+			Field:       row.FieldName,
+			Previous:    row.PreviousValue,
+			Replacement: row.ReplacementValue,
+		})
+	}
+
+	return entries
+}
+
+// historyFor returns the rows for a resource ordered by CreatedAt. LastActionDate
+// is a date column and loses the time of day once stored, so it cannot order
+// the edits of one day.
+func historyFor(history []Metadata, resourceID string) []Metadata {
+	var rows []Metadata
+	for _, row := range history {
+		if row.ResourceID == resourceID {
+			rows = append(rows, row)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].CreatedAt.Before(rows[j].CreatedAt.Time)
+	})
+	return rows
+}
+
+// setFieldFromString parses s, as written by formatFieldValue, into field.
+// An empty string clears pointer fields.
+func setFieldFromString(field reflect.Value, s string) error {
+	if field.Kind() == reflect.Pointer {
+		if s == "" {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		value := reflect.New(field.Type().Elem())
+		if err := setFieldFromString(value.Elem(), s); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		if s == "" {
+			field.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+		t, err := ParseTime(s)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
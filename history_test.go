@@ -0,0 +1,105 @@
+package hsds_types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// scheduleHistory returns a schedule created on March 1 at 09:00 opening and
+// changed to 10:00 at 16:00 on March 3, with the Metadata rows for both
+func scheduleHistory(t *testing.T) (*Schedule, []Metadata) {
+	t.Helper()
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	edited := time.Date(2024, 3, 3, 16, 0, 0, 0, time.UTC)
+
+	f := NewFactory(FixedClock{created}, NewSequenceIDGenerator(1))
+	original, err := f.NewSchedule(&ScheduleOptions{OpensAt: NewFlexClock(time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var history []Metadata
+	create := f.NewChangeSet("importer")
+	if err := create.Track(nil, original); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range create.Rows {
+		history = append(history, *row)
+	}
+
+	current := Clone(original)
+	current.OpensAt = NewFlexClock(time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC))
+	edit := NewFactory(FixedClock{edited}, NewSequenceIDGenerator(100)).NewChangeSet("editor")
+	if err := edit.Track(original, current); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range edit.Rows {
+		history = append(history, *row)
+	}
+	return current, history
+}
+
+func TestAsOf(t *testing.T) {
+	current, history := scheduleHistory(t)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		want    string
+		wantErr error
+	}{
+		{"before the edit on the same day", time.Date(2024, 3, 3, 14, 0, 0, 0, time.UTC), "09:00:00", nil},
+		{"after the edit", time.Date(2024, 3, 3, 17, 0, 0, 0, time.UTC), "10:00:00", nil},
+		{"at the edit", time.Date(2024, 3, 3, 16, 0, 0, 0, time.UTC), "10:00:00", nil},
+		{"before creation", time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), "", ErrNotCreatedYet},
+	}
+	for _, tt := range tests {
+		got, err := AsOf(current, history, tt.at)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.OpensAt.String() != tt.want {
+			t.Errorf("%s: opens_at = %s, want %s", tt.name, got.OpensAt, tt.want)
+		}
+	}
+	if current.OpensAt.String() != "10:00:00" {
+		t.Errorf("AsOf modified the entity: opens_at = %s", current.OpensAt)
+	}
+}
+
+func TestAsOfIgnoresStoredDatePrecision(t *testing.T) {
+	current, history := scheduleHistory(t)
+	// a date column keeps only the day of each change
+	for i := range history {
+		history[i].LastActionDate = *NewFlexDate(history[i].LastActionDate.Time)
+	}
+	got, err := AsOf(current, history, time.Date(2024, 3, 3, 14, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.OpensAt.String() != "09:00:00" {
+		t.Errorf("opens_at = %s, want 09:00:00", got.OpensAt)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	current, history := scheduleHistory(t)
+	entries := Timeline(history, current.ID)
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	if entries[0].ActionType != ActionTypeCreate || entries[1].ActionType != ActionTypeUpdate {
+		t.Errorf("actions = %s, %s; want create, update", entries[0].ActionType, entries[1].ActionType)
+	}
+	if entries[1].UpdatedBy != "editor" || len(entries[1].Changes) != 1 || entries[1].Changes[0].Field != "opens_at" {
+		t.Errorf("update entry = %+v, want one opens_at change by editor", entries[1])
+	}
+	if !entries[0].At.Before(entries[1].At) {
+		t.Errorf("entries out of order: %v, %v", entries[0].At, entries[1].At)
+	}
+}
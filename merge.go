@@ -0,0 +1,467 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SurvivorshipStrategy decides which record's value survives a merge
+type SurvivorshipStrategy string
+
+// SurvivorshipStrategy values
+const (
+	// SurvivorshipMostRecent keeps the value from the record with the latest UpdatedAt
+	SurvivorshipMostRecent SurvivorshipStrategy = "most_recent"
+	// SurvivorshipMostComplete keeps the value from the record with more populated fields
+	SurvivorshipMostComplete SurvivorshipStrategy = "most_complete"
+	// SurvivorshipTrustedSource keeps the value from the record with the higher ranked source
+	SurvivorshipTrustedSource SurvivorshipStrategy = "trusted_source"
+)
+
+// MergeRules configures how two duplicate records are combined
+type MergeRules struct {
+	// Default applies to every field without an entry in Fields, default most_recent
+	Default SurvivorshipStrategy
+	// Fields overrides the strategy per JSON field name, e.g. "description"
+	Fields map[string]SurvivorshipStrategy
+	// SourceRank lists sources from most to least trusted for trusted_source
+	SourceRank []string
+	// SourceOf returns the source of a record ID for trusted_source
+	SourceOf func(id string) string
+	// UpdatedBy and CallID are recorded on the emitted Metadata rows
+	UpdatedBy string
+	CallID    string
+	// Factory creates the Metadata rows and stamps UpdatedAt, default the package Factory
+	Factory *Factory
+}
+
+// Merge combines duplicate b into survivor a and returns the golden record,
+// which keeps a's ID, plus one Metadata row per field whose value changed.
+// Empty values never replace populated ones.
+func Merge[T any](a, b *T, rules *MergeRules) (*T, []*Metadata, error) {
+	if a == nil || b == nil {
+		return nil, nil, fmt.Errorf("both records are required")
+	}
+	if rules == nil {
+		rules = &MergeRules{}
+	}
+
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	if va.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("cannot merge %s: not a struct", va.Type())
+	}
+
+	golden := new(T)
+	*golden = *a
+	vg := reflect.ValueOf(golden).Elem()
+
+	survivorID := va.FieldByName("ID").String()
+	resourceType := MetadataResourceTypeEnum(resourceTypeOf(va.Type()))
+	completeA, completeB := populatedFields(va), populatedFields(vb)
+
+	var history []*Metadata
+	for _, field := range dataFields(va.Type()) {
+		fa, fb := va.Field(field.index), vb.Field(field.index)
+		if isEmptyValue(fb) {
+			continue
+		}
+
+		strategy := rules.Default
+		if s, ok := rules.Fields[field.name]; ok {
+			strategy = s
+		}
+
+		takeB := isEmptyValue(fa)
+		if !takeB {
+			switch strategy {
+			case SurvivorshipMostComplete:
+				takeB = completeB > completeA
+			case SurvivorshipTrustedSource:
+				takeB = rules.sourceRank(vb) < rules.sourceRank(va)
+			case SurvivorshipMostRecent, "":
+				takeB = lastModified(vb).After(lastModified(va))
+			default:
+				return nil, nil, fmt.Errorf("unknown survivorship strategy %q for field %s", strategy, field.name)
+			}
+		}
+		if !takeB || reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			continue
+		}
+
+		vg.Field(field.index).Set(fb)
+		meta, err := factoryOr(rules.Factory).NewMetadata(MetadataOptions{
+			ResourceID:       survivorID,
+			CallID:           rules.CallID,
+			ResourceType:     resourceType,
+			LastActionType:   ActionTypeMerge,
+			FieldName:        field.name,
+			PreviousValue:    formatFieldValue(fa),
+			ReplacementValue: formatFieldValue(fb),
+			UpdatedBy:        rules.UpdatedBy,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		history = append(history, meta)
+	}
+
+	if f := vg.FieldByName("UpdatedAt"); f.IsValid() {
+		f.Set(reflect.ValueOf(FlexTime{factoryOr(rules.Factory).now()}))
+	}
+
+	return golden, history, nil
+}
+
+// MergeOrganizations merges organization duplicateID into survivorID within the
+// dataset, re-pointing every child record and appending the Metadata history
+func (ds *Dataset) MergeOrganizations(survivorID, duplicateID string, rules *MergeRules) (*Organization, []*Metadata, error) {
+	return mergeInDataset(ds, &ds.Organizations, survivorID, duplicateID, rules, "organization_id", "parent_organization_id")
+}
+
+// MergeServices merges service duplicateID into survivorID within the
+// dataset, then merges service at location rows left linking the survivor to
+// the same location twice. On error the dataset is left unchanged.
+func (ds *Dataset) MergeServices(survivorID, duplicateID string, rules *MergeRules) (*Service, []*Metadata, error) {
+	saved := ds.copyTables()
+	golden, history, err := mergeInDataset(ds, &ds.Services, survivorID, duplicateID, rules, "service_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	merged, err := ds.mergeDuplicateServiceAtLocations(survivorID, rules)
+	if err != nil {
+		*ds = saved
+		return nil, nil, err
+	}
+	return golden, append(history, merged...), nil
+}
+
+// MergeLocations merges location duplicateID into survivorID within the
+// dataset, then merges service at location rows left linking the same
+// service to the survivor twice. On error the dataset is left unchanged.
+func (ds *Dataset) MergeLocations(survivorID, duplicateID string, rules *MergeRules) (*Location, []*Metadata, error) {
+	saved := ds.copyTables()
+	golden, history, err := mergeInDataset(ds, &ds.Locations, survivorID, duplicateID, rules, "location_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	merged, err := ds.mergeDuplicateServiceAtLocations(survivorID, rules)
+	if err != nil {
+		*ds = saved
+		return nil, nil, err
+	}
+	return golden, append(history, merged...), nil
+}
+
+// MergeContacts merges contact duplicateID into survivorID within the dataset
+func (ds *Dataset) MergeContacts(survivorID, duplicateID string, rules *MergeRules) (*Contact, []*Metadata, error) {
+	return mergeInDataset(ds, &ds.Contacts, survivorID, duplicateID, rules, "contact_id")
+}
+
+// MergePhones merges phone duplicateID into survivorID within the dataset.
+// No table has a foreign key to phones, so only attribute links are re-pointed.
+func (ds *Dataset) MergePhones(survivorID, duplicateID string, rules *MergeRules) (*Phone, []*Metadata, error) {
+	return mergeInDataset(ds, &ds.Phones, survivorID, duplicateID, rules)
+}
+
+// mergeDuplicateServiceAtLocations merges service at location rows of the
+// same service and location pair, keeping the first, when either side is
+// mergedID. Schedules, phones, contacts and attributes of the dropped rows
+// are re-pointed to the kept row.
+func (ds *Dataset) mergeDuplicateServiceAtLocations(mergedID string, rules *MergeRules) ([]*Metadata, error) {
+	var history []*Metadata
+	for {
+		var keepID, dropID string
+		seen := make(map[[2]string]string)
+		for _, sal := range ds.ServiceAtLocations {
+			if sal.ServiceID != mergedID && sal.LocationID != mergedID {
+				continue
+			}
+			key := [2]string{sal.ServiceID, sal.LocationID}
+			if id, ok := seen[key]; ok {
+				keepID, dropID = id, sal.ID
+				break
+			}
+			seen[key] = sal.ID
+		}
+		if dropID == "" {
+			return history, nil
+		}
+		_, merged, err := mergeInDataset(ds, &ds.ServiceAtLocations, keepID, dropID, rules, "service_at_location_id")
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, merged...)
+	}
+}
+
+// mergeInDataset merges two records of one table, replaces the survivor,
+// drops the duplicate and re-points foreign keys and attribute links. On error
+// the dataset is left unchanged.
+func mergeInDataset[T any](ds *Dataset, items *[]T, survivorID, duplicateID string, rules *MergeRules, foreignKeys ...string) (*T, []*Metadata, error) {
+	if survivorID == duplicateID {
+		return nil, nil, fmt.Errorf("cannot merge record %s into itself", survivorID)
+	}
+	if rules == nil {
+		rules = &MergeRules{}
+	}
+
+	si, di := indexByID(*items, survivorID), indexByID(*items, duplicateID)
+	if si < 0 {
+		return nil, nil, fmt.Errorf("survivor %s not found", survivorID)
+	}
+	if di < 0 {
+		return nil, nil, fmt.Errorf("duplicate %s not found", duplicateID)
+	}
+
+	golden, history, err := Merge(&(*items)[si], &(*items)[di], rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// every Metadata row is built before the dataset changes, so a failure
+	// leaves it as it was
+	resourceType := MetadataResourceTypeEnum(resourceTypeOf(reflect.TypeOf(golden).Elem()))
+	rewrites, repointed, err := ds.repoint(string(resourceType), survivorID, duplicateID, rules, foreignKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	history = append(history, repointed...)
+
+	removed, err := factoryOr(rules.Factory).NewMetadata(MetadataOptions{
+		ResourceID:       duplicateID,
+		CallID:           rules.CallID,
+		ResourceType:     resourceType,
+		LastActionType:   ActionTypeMerge,
+		FieldName:        "id",
+		PreviousValue:    duplicateID,
+		ReplacementValue: survivorID,
+		UpdatedBy:        rules.UpdatedBy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	history = append(history, removed)
+
+	(*items)[si] = *golden
+	for _, rewrite := range rewrites {
+		rewrite()
+	}
+	*items = append((*items)[:di], (*items)[di+1:]...)
+	for _, meta := range history {
+		ds.Metadata = append(ds.Metadata, *meta)
+	}
+
+	return golden, history, nil
+}
+
+// repoint plans the rewrite of every foreign key and attribute link that
+// references duplicateID to survivorID. It returns the rewrites, to be run
+// once nothing else can fail, and one Metadata row per rewritten field.
+func (ds *Dataset) repoint(linkEntity, survivorID, duplicateID string, rules *MergeRules, foreignKeys []string) ([]func(), []*Metadata, error) {
+	var (
+		rewrites []func()
+		history  []*Metadata
+	)
+	record := func(child reflect.Value, field string, rewrite func()) error {
+		meta, err := factoryOr(rules.Factory).NewMetadata(MetadataOptions{
+			ResourceID:       child.FieldByName("ID").String(),
+			CallID:           rules.CallID,
+			ResourceType:     MetadataResourceTypeEnum(resourceTypeOf(child.Type())),
+			LastActionType:   ActionTypeMerge,
+			FieldName:        field,
+			PreviousValue:    duplicateID,
+			ReplacementValue: survivorID,
+			UpdatedBy:        rules.UpdatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		rewrites = append(rewrites, rewrite)
+		history = append(history, meta)
+		return nil
+	}
+
+	tables := reflect.ValueOf(ds).Elem()
+	for t := 0; t < tables.NumField(); t++ {
+		table := tables.Field(t)
+		if table.Kind() != reflect.Slice || table.Type().Elem() == reflect.TypeOf(Metadata{}) {
+			continue
+		}
+		for i := 0; i < table.Len(); i++ {
+			child := table.Index(i)
+			for _, field := range dataFields(child.Type()) {
+				if !contains(foreignKeys, field.name) {
+					continue
+				}
+				fv := child.Field(field.index)
+				var rewrite func()
+				switch {
+				case fv.Kind() == reflect.String && fv.String() == duplicateID:
+					rewrite = func() { fv.SetString(survivorID) }
+				case fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().String() == duplicateID:
+					rewrite = func() {
+						id := survivorID
+						fv.Set(reflect.ValueOf(&id))
+					}
+				default:
+					continue
+				}
+				if err := record(child, field.name, rewrite); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	for i := range ds.Attributes {
+		attr := &ds.Attributes[i]
+		if attr.LinkID == duplicateID && strings.EqualFold(attr.LinkEntity, linkEntity) {
+			if err := record(reflect.ValueOf(attr).Elem(), "link_id", func() { attr.LinkID = survivorID }); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return rewrites, history, nil
+}
+
+// sourceRank returns the position of a record's source in SourceRank,
+// or len(SourceRank) when the source is unknown
+func (r *MergeRules) sourceRank(record reflect.Value) int {
+	if r.SourceOf == nil {
+		return len(r.SourceRank)
+	}
+	source := r.SourceOf(record.FieldByName("ID").String())
+	for i, s := range r.SourceRank {
+		if s == source {
+			return i
+		}
+	}
+	return len(r.SourceRank)
+}
+
+// structField is a data-carrying struct field addressed by its JSON name
+type structField struct {
+	index int
+	name  string
+}
+
+// dataFields lists the JSON-serialized fields of an HSDS struct, excluding
+// the ID, timestamps and GORM relation fields
+func dataFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		switch name {
+		case "", "-", "id", "created_at", "updated_at":
+			continue
+		}
+		fields = append(fields, structField{index: i, name: name})
+	}
+	return fields
+}
+
+// jsonName returns the JSON key of a struct field
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+// populatedFields counts the non-empty data fields of a record
+func populatedFields(v reflect.Value) int {
+	count := 0
+	for _, field := range dataFields(v.Type()) {
+		if !isEmptyValue(v.Field(field.index)) {
+			count++
+		}
+	}
+	return count
+}
+
+// lastModified returns UpdatedAt, falling back to CreatedAt
+func lastModified(v reflect.Value) time.Time {
+	if f := v.FieldByName("UpdatedAt"); f.IsValid() {
+		if t, _ := asTime(f.Interface()); !t.IsZero() {
+			return t
+		}
+	}
+	if f := v.FieldByName("CreatedAt"); f.IsValid() {
+		t, _ := asTime(f.Interface())
+		return t
+	}
+	return time.Time{}
+}
+
+// isEmptyValue reports whether v is nil, the zero value or an empty string
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil() || isEmptyValue(v.Elem())
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// formatFieldValue renders a field value for Metadata previous/replacement values
+func formatFieldValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// resourceTypeOf returns the snake_case HSDS resource name of a struct type
+func resourceTypeOf(t reflect.Type) string {
+	name := []rune(t.Name())
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(name[i-1]) || (i+1 < len(name) && unicode.IsLower(name[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// indexByID returns the position of the record with the given ID, or -1
+func indexByID[T any](items []T, id string) int {
+	for i := range items {
+		if reflect.ValueOf(&items[i]).Elem().FieldByName("ID").String() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// contains reports whether values includes s
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
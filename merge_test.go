@@ -0,0 +1,180 @@
+package hsds_types
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testID returns the n-th UUID of a readable test sequence
+func testID(n int) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", n)
+}
+
+func TestMergeSurvivorship(t *testing.T) {
+	older := FlexTime{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := FlexTime{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	a := Organization{ID: testID(1), Name: "Food Bank", Description: "Groceries", Email: strPtr("a@example.org"), UpdatedAt: older}
+	b := Organization{ID: testID(2), Name: "Food Bank Inc", Description: "", Email: strPtr("b@example.org"), Website: strPtr("https://example.org"), UpdatedAt: newer}
+
+	tests := []struct {
+		name      string
+		rules     *MergeRules
+		wantName  string
+		wantEmail string
+		changed   int
+	}{
+		{"most recent", nil, "Food Bank Inc", "b@example.org", 3},
+		{"most complete", &MergeRules{Default: SurvivorshipMostComplete}, "Food Bank", "a@example.org", 1},
+		{"per field", &MergeRules{Default: SurvivorshipMostComplete, Fields: map[string]SurvivorshipStrategy{"email": SurvivorshipMostRecent}}, "Food Bank", "b@example.org", 2},
+		{"trusted source", &MergeRules{
+			Default:    SurvivorshipTrustedSource,
+			SourceRank: []string{"county", "scraper"},
+			SourceOf: func(id string) string {
+				if id == testID(1) {
+					return "county"
+				}
+				return "scraper"
+			},
+		}, "Food Bank", "a@example.org", 1},
+	}
+	for _, tt := range tests {
+		golden, history, err := Merge(&a, &b, tt.rules)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if golden.ID != a.ID || golden.Name != tt.wantName || *golden.Email != tt.wantEmail {
+			t.Errorf("%s: golden = %s %q %q", tt.name, golden.ID, golden.Name, *golden.Email)
+		}
+		if golden.Description != "Groceries" || golden.Website == nil {
+			t.Errorf("%s: empty values replaced populated ones or were not filled: %+v", tt.name, golden)
+		}
+		if len(history) != tt.changed {
+			t.Errorf("%s: history = %d rows, want %d", tt.name, len(history), tt.changed)
+		}
+	}
+
+	if _, _, err := Merge(&a, &b, &MergeRules{Default: "coin_flip"}); err == nil {
+		t.Error("Merge accepted an unknown strategy")
+	}
+}
+
+func TestMergeStampsFactoryClock(t *testing.T) {
+	a := Service{ID: testID(1), OrganizationID: testID(9), Name: "Pantry"}
+	b := Service{ID: testID(2), OrganizationID: testID(9), Name: "Pantry", Email: strPtr("x@example.org")}
+	golden, history, err := Merge(&a, &b, &MergeRules{Factory: newTestFactory(), UpdatedBy: "merger"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !golden.UpdatedAt.Equal(testTime) {
+		t.Errorf("UpdatedAt = %v, want %v", golden.UpdatedAt, testTime)
+	}
+	if len(history) != 1 || !history[0].CreatedAt.Equal(testTime) || history[0].UpdatedBy != "merger" {
+		t.Errorf("history = %+v", history)
+	}
+}
+
+func TestMergeLocationsDedupesServiceAtLocations(t *testing.T) {
+	svc, keep, drop := testID(1), testID(2), testID(3)
+	salKeep, salDrop := testID(4), testID(5)
+	ds := &Dataset{
+		Services:  []Service{{ID: svc, OrganizationID: testID(9), Name: "Pantry"}},
+		Locations: []Location{{ID: keep}, {ID: drop, Name: strPtr("Annex")}},
+		ServiceAtLocations: []ServiceAtLocation{
+			{ID: salKeep, ServiceID: svc, LocationID: keep},
+			{ID: salDrop, ServiceID: svc, LocationID: drop, Description: strPtr("Side door")},
+		},
+		Schedules:  []Schedule{{ID: testID(6), ServiceAtLocationID: &salDrop}},
+		Attributes: []Attribute{{ID: testID(7), LinkEntity: "service_at_location", LinkID: salDrop}},
+	}
+	rules := &MergeRules{Factory: NewFactory(FixedClock{testTime}, NewSequenceIDGenerator(100))}
+
+	golden, history, err := ds.MergeLocations(keep, drop, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if golden.Name == nil || *golden.Name != "Annex" || len(ds.Locations) != 1 {
+		t.Errorf("golden = %+v, locations = %d", golden, len(ds.Locations))
+	}
+	if len(ds.ServiceAtLocations) != 1 || ds.ServiceAtLocations[0].ID != salKeep {
+		t.Fatalf("service at locations = %+v, want only %s", ds.ServiceAtLocations, salKeep)
+	}
+	if d := ds.ServiceAtLocations[0].Description; d == nil || *d != "Side door" {
+		t.Errorf("kept row description = %v, want the dropped row's", d)
+	}
+	if *ds.Schedules[0].ServiceAtLocationID != salKeep || ds.Attributes[0].LinkID != salKeep {
+		t.Error("schedule and attribute were not re-pointed to the kept service at location")
+	}
+	if len(ds.Metadata) != len(history) {
+		t.Errorf("dataset metadata = %d rows, returned history = %d", len(ds.Metadata), len(history))
+	}
+}
+
+func TestMergePhones(t *testing.T) {
+	keep, drop := testID(1), testID(2)
+	ds := &Dataset{
+		Phones:     []Phone{{ID: keep, Number: "+12065551212"}, {ID: drop, Number: "+12065551212", Description: strPtr("Main line")}},
+		Attributes: []Attribute{{ID: testID(3), LinkEntity: "phone", LinkID: drop}},
+	}
+	golden, _, err := ds.MergePhones(keep, drop, &MergeRules{Factory: NewFactory(FixedClock{testTime}, NewSequenceIDGenerator(100))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds.Phones) != 1 || golden.Description == nil || ds.Attributes[0].LinkID != keep {
+		t.Errorf("phones = %+v, attribute link = %s", ds.Phones, ds.Attributes[0].LinkID)
+	}
+
+	if _, _, err := ds.MergePhones(keep, keep, nil); err == nil {
+		t.Error("MergePhones merged a phone into itself")
+	}
+	if _, _, err := ds.MergePhones(keep, testID(9), nil); err == nil {
+		t.Error("MergePhones accepted a missing duplicate")
+	}
+}
+
+func TestMergeFailureLeavesDatasetUnchanged(t *testing.T) {
+	svc, keep, drop := testID(1), testID(2), testID(3)
+	tests := []struct {
+		name  string
+		ds    *Dataset
+		merge func(ds *Dataset) error
+	}{
+		{
+			// a child with a non-UUID ID cannot get a Metadata row
+			name: "re-pointed child",
+			ds: &Dataset{
+				Organizations: []Organization{{ID: keep, Name: "Food Bank"}, {ID: drop, Name: "Food Bank", Email: strPtr("x@example.org")}},
+				Services:      []Service{{ID: svc, OrganizationID: keep}, {ID: "legacy-7", OrganizationID: drop}},
+			},
+			merge: func(ds *Dataset) error {
+				_, _, err := ds.MergeOrganizations(keep, drop, nil)
+				return err
+			},
+		},
+		{
+			// the location merge succeeds, merging its service at location rows fails
+			name: "service at location rows",
+			ds: &Dataset{
+				Locations: []Location{{ID: keep}, {ID: drop, Name: strPtr("Annex")}},
+				ServiceAtLocations: []ServiceAtLocation{
+					{ID: testID(4), ServiceID: svc, LocationID: keep},
+					{ID: testID(5), ServiceID: svc, LocationID: drop, Description: strPtr("Side door")},
+				},
+				Schedules: []Schedule{{ID: "legacy-8", ServiceAtLocationID: strPtr(testID(5))}},
+			},
+			merge: func(ds *Dataset) error {
+				_, _, err := ds.MergeLocations(keep, drop, nil)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		before := tt.ds.Clone()
+		if err := tt.merge(tt.ds); err == nil {
+			t.Fatalf("%s: merge succeeded", tt.name)
+		}
+		if d := DiffDatasets(before, tt.ds); len(d.Tables) != 0 {
+			t.Errorf("%s: dataset changed by a failed merge: %+v", tt.name, d.Tables)
+		}
+	}
+}
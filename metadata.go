@@ -0,0 +1,126 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// resourceTypes holds the resource type of every table in Dataset
+var resourceTypes = func() map[MetadataResourceTypeEnum]bool {
+	types := make(map[MetadataResourceTypeEnum]bool)
+	t := reflect.TypeOf(Dataset{})
+	for i := 0; i < t.NumField(); i++ {
+		types[MetadataResourceTypeEnum(resourceTypeOf(t.Field(i).Type.Elem()))] = true
+	}
+	return types
+}()
+
+// ValidateResourceType ensures s names an HSDS table
+func ValidateResourceType(s string) error {
+	if !resourceTypes[MetadataResourceTypeEnum(s)] {
+		return fmt.Errorf("invalid resource type %q: must be one of the HSDS tables", s)
+	}
+	return nil
+}
+
+// ValidateActionType ensures s is a known Metadata action
+func ValidateActionType(s string) error {
+	switch MetadataLastActionTypeEnum(s) {
+	case ActionTypeCreate, ActionTypeUpdate, ActionTypeDelete, ActionTypeMerge, ActionTypeVerify:
+		return nil
+	}
+	return fmt.Errorf("invalid action type %q: must be create, update, delete, merge or verify", s)
+}
+
+// ResourceTypeOf returns the resource type for an HSDS record or pointer to one
+func ResourceTypeOf(entity any) (MetadataResourceTypeEnum, error) {
+	v := recordValue(entity)
+	if !v.IsValid() {
+		return "", fmt.Errorf("%T is not an HSDS record", entity)
+	}
+	resourceType := MetadataResourceTypeEnum(resourceTypeOf(v.Type()))
+	if !resourceTypes[resourceType] {
+		return "", fmt.Errorf("%T is not an HSDS record", entity)
+	}
+	return resourceType, nil
+}
+
+// ChangeSet collects the Metadata rows written by a single save so that every
+// field edit shares one CallID, UpdatedBy and time, recorded as CreatedAt and
+// LastActionDate
+type ChangeSet struct {
+	CallID    string
+	UpdatedBy string
+	At        time.Time
+	Rows      []*Metadata
+
+	factory *Factory
+}
+
+// NewChangeSet starts a change set with a fresh CallID
+func NewChangeSet(updatedBy string) *ChangeSet {
+	return defaultFactory.NewChangeSet(updatedBy)
+}
+
+// NewChangeSet starts a change set whose CallID, time and rows come from the factory
+func (f *Factory) NewChangeSet(updatedBy string) *ChangeSet {
+	return &ChangeSet{
+		CallID:    f.IDs.NewID(),
+		UpdatedBy: updatedBy,
+		At:        f.now(),
+		factory:   f,
+	}
+}
+
+// Record adds one Metadata row; CallID, UpdatedBy and the time come from the change set
+func (cs *ChangeSet) Record(opts MetadataOptions) (*Metadata, error) {
+	opts.CallID = cs.CallID
+	opts.UpdatedBy = cs.UpdatedBy
+	meta, err := factoryOr(cs.factory).NewMetadata(opts)
+	if err != nil {
+		return nil, err
+	}
+	meta.CreatedAt, meta.LastActionDate = FlexTime{cs.At}, *NewFlexDate(cs.At)
+	cs.Rows = append(cs.Rows, meta)
+	return meta, nil
+}
+
+// Track diffs two versions of a record and adds a row per changed field,
+// see TrackChanges
+func (cs *ChangeSet) Track(old, new any) error {
+	rows, err := factoryOr(cs.factory).TrackChanges(old, new, cs.UpdatedBy, cs.CallID)
+	if err != nil {
+		return err
+	}
+	for _, meta := range rows {
+		meta.CreatedAt, meta.LastActionDate = FlexTime{cs.At}, *NewFlexDate(cs.At)
+	}
+	cs.Rows = append(cs.Rows, rows...)
+	return nil
+}
+
+// Verify records that entity was reviewed and confirmed without changes
+func (cs *ChangeSet) Verify(entity any) error {
+	resourceType, err := ResourceTypeOf(entity)
+	if err != nil {
+		return err
+	}
+	id := recordValue(entity).FieldByName("ID").String()
+	_, err = cs.Record(MetadataOptions{
+		ResourceID:       id,
+		ResourceType:     resourceType,
+		LastActionType:   ActionTypeVerify,
+		FieldName:        "id",
+		PreviousValue:    id,
+		ReplacementValue: id,
+	})
+	return err
+}
+
+// Apply appends the collected rows to the dataset's Metadata table
+func (cs *ChangeSet) Apply(ds *Dataset) {
+	for _, meta := range cs.Rows {
+		ds.Metadata = append(ds.Metadata, *meta)
+	}
+}
@@ -0,0 +1,882 @@
+package hsds_types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Optional is a patch field that distinguishes "unset" (leave the field
+// alone) from "set to null" (clear it) and "set to a value". Decoded from
+// JSON, an absent key is unset and an explicit null is Null.
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// Some returns an Optional set to v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Set: true, Value: v}
+}
+
+// Null returns an Optional that clears the field
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// Get returns the value and whether the Optional holds one
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set && !o.Null
+}
+
+// MarshalJSON encodes the value, or null when null or unset. The patch
+// structs' own MarshalJSON leaves unset fields out.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON marks the Optional as set and decodes the value
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		o.Null = true
+		return nil
+	}
+	o.Null = false
+	return json.Unmarshal(data, &o.Value)
+}
+
+// OrganizationPatch contains the Organization fields to update; unset fields are left unchanged
+type OrganizationPatch struct {
+	ParentOrganizationID Optional[string] `json:"parent_organization_id"`
+	Name                 Optional[string] `json:"name"`
+	AlternateName        Optional[string] `json:"alternate_name"`
+	Description          Optional[string] `json:"description"`
+	Email                Optional[string] `json:"email"`
+	LegalStatus          Optional[string] `json:"legal_status"`
+	Logo                 Optional[string] `json:"logo"`
+	TaxID                Optional[string] `json:"tax_id"`
+	TaxStatus            Optional[string] `json:"tax_status"`
+	URI                  Optional[string] `json:"uri"`
+	Website              Optional[string] `json:"website"`
+	YearIncorporated     Optional[int]    `json:"year_incorporated"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p OrganizationPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the organization with the fields set in patch, validated with
+// the same rules as NewOrganization, and returns the JSON names of the changed fields
+func (o *Organization) Apply(patch *OrganizationPatch) ([]string, error) {
+	return defaultFactory.Apply(o, patch)
+}
+
+// validatePatch checks the fields of a patched Organization with the rules of its
+// constructor, keyed by JSON name
+func (o *Organization) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"parent_organization_id": validForeignKey(factory, "parent organization", o.ParentOrganizationID),
+	}
+}
+
+// OrganizationIdentifierPatch contains the OrganizationIdentifier fields to update; unset fields are left unchanged
+type OrganizationIdentifierPatch struct {
+	OrganizationID   Optional[string] `json:"organization_id"`
+	IdentifierScheme Optional[string] `json:"identifier_scheme"`
+	IdentifierType   Optional[string] `json:"identifier_type"`
+	Identifier       Optional[string] `json:"identifier"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p OrganizationIdentifierPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the organization identifier with the fields set in patch, validated with
+// the same rules as NewOrganizationIdentifier, and returns the JSON names of the changed fields
+func (oi *OrganizationIdentifier) Apply(patch *OrganizationIdentifierPatch) ([]string, error) {
+	return defaultFactory.Apply(oi, patch)
+}
+
+// validatePatch checks the fields of a patched OrganizationIdentifier with the rules of its
+// constructor, keyed by JSON name
+func (oi *OrganizationIdentifier) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", &oi.OrganizationID),
+	}
+}
+
+// URLPatch contains the URL fields to update; unset fields are left unchanged
+type URLPatch struct {
+	OrganizationID Optional[string] `json:"organization_id"`
+	ServiceID      Optional[string] `json:"service_id"`
+	Label          Optional[string] `json:"label"`
+	URL            Optional[string] `json:"url"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p URLPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the URL with the fields set in patch, validated with
+// the same rules as NewURL, and returns the JSON names of the changed fields
+func (u *URL) Apply(patch *URLPatch) ([]string, error) {
+	return defaultFactory.Apply(u, patch)
+}
+
+// validatePatch checks the fields of a patched URL with the rules of its
+// constructor, keyed by JSON name
+func (u *URL) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", u.OrganizationID),
+		"service_id":      validForeignKey(factory, "service", u.ServiceID),
+	}
+}
+
+// FundingPatch contains the Funding fields to update; unset fields are left unchanged
+type FundingPatch struct {
+	OrganizationID Optional[string] `json:"organization_id"`
+	ServiceID      Optional[string] `json:"service_id"`
+	Source         Optional[string] `json:"source"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p FundingPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the funding with the fields set in patch, validated with
+// the same rules as NewFunding, and returns the JSON names of the changed fields
+func (f *Funding) Apply(patch *FundingPatch) ([]string, error) {
+	return defaultFactory.Apply(f, patch)
+}
+
+// validatePatch checks the fields of a patched Funding with the rules of its
+// constructor, keyed by JSON name
+func (f *Funding) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", f.OrganizationID),
+		"service_id":      validForeignKey(factory, "service", f.ServiceID),
+	}
+}
+
+// UnitPatch contains the Unit fields to update; unset fields are left unchanged
+type UnitPatch struct {
+	Name       Optional[string] `json:"name"`
+	Scheme     Optional[string] `json:"scheme"`
+	Identifier Optional[string] `json:"identifier"`
+	URI        Optional[string] `json:"uri"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p UnitPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the unit with the fields set in patch, validated with
+// the same rules as NewUnit, and returns the JSON names of the changed fields
+func (u *Unit) Apply(patch *UnitPatch) ([]string, error) {
+	return defaultFactory.Apply(u, patch)
+}
+
+// ProgramPatch contains the Program fields to update; unset fields are left unchanged
+type ProgramPatch struct {
+	OrganizationID Optional[string] `json:"organization_id"`
+	Name           Optional[string] `json:"name"`
+	AlternateName  Optional[string] `json:"alternate_name"`
+	Description    Optional[string] `json:"description"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ProgramPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the program with the fields set in patch, validated with
+// the same rules as NewProgram, and returns the JSON names of the changed fields
+func (p *Program) Apply(patch *ProgramPatch) ([]string, error) {
+	return defaultFactory.Apply(p, patch)
+}
+
+// validatePatch checks the fields of a patched Program with the rules of its
+// constructor, keyed by JSON name
+func (p *Program) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", &p.OrganizationID),
+	}
+}
+
+// ServicePatch contains the Service fields to update; unset fields are left unchanged
+type ServicePatch struct {
+	OrganizationID         Optional[string]            `json:"organization_id"`
+	ProgramID              Optional[string]            `json:"program_id"`
+	Name                   Optional[string]            `json:"name"`
+	AlternateName          Optional[string]            `json:"alternate_name"`
+	Description            Optional[string]            `json:"description"`
+	URL                    Optional[string]            `json:"url"`
+	Email                  Optional[string]            `json:"email"`
+	Status                 Optional[ServiceStatusEnum] `json:"status"`
+	InterpretationServices Optional[string]            `json:"interpretation_services"`
+	ApplicationProcess     Optional[string]            `json:"application_process"`
+	FeesDescription        Optional[string]            `json:"fees_description"`
+	WaitTime               Optional[string]            `json:"wait_time"`
+	Fees                   Optional[string]            `json:"fees"`
+	Accreditations         Optional[string]            `json:"accreditations"`
+	EligibilityDescription Optional[string]            `json:"eligibility_description"`
+	MinimumAge             Optional[float64]           `json:"minimum_age"`
+	MaximumAge             Optional[float64]           `json:"maximum_age"`
+	AssuredDate            Optional[FlexDate]          `json:"assured_date"`
+	AssurerEmail           Optional[string]            `json:"assurer_email"`
+	Licenses               Optional[string]            `json:"licenses"`
+	Alert                  Optional[string]            `json:"alert"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ServicePatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the service with the fields set in patch, validated with
+// the same rules as NewService, and returns the JSON names of the changed fields
+func (s *Service) Apply(patch *ServicePatch) ([]string, error) {
+	return defaultFactory.Apply(s, patch)
+}
+
+// validatePatch checks the fields of a patched Service with the rules of its
+// constructor, keyed by JSON name
+func (s *Service) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", &s.OrganizationID),
+		"program_id":      validForeignKey(factory, "program", s.ProgramID),
+	}
+}
+
+// ServiceAreaPatch contains the ServiceArea fields to update; unset fields are left unchanged
+type ServiceAreaPatch struct {
+	ServiceID           Optional[string]         `json:"service_id"`
+	ServiceAtLocationID Optional[string]         `json:"service_at_location_id"`
+	Name                Optional[string]         `json:"name"`
+	Description         Optional[string]         `json:"description"`
+	Extent              Optional[string]         `json:"extent"`
+	ExtentType          Optional[ExtentTypeEnum] `json:"extent_type"`
+	URI                 Optional[string]         `json:"uri"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ServiceAreaPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the service area with the fields set in patch, validated with
+// the same rules as NewServiceArea, and returns the JSON names of the changed fields
+func (sa *ServiceArea) Apply(patch *ServiceAreaPatch) ([]string, error) {
+	return defaultFactory.Apply(sa, patch)
+}
+
+// validatePatch checks the fields of a patched ServiceArea with the rules of its
+// constructor, keyed by JSON name
+func (sa *ServiceArea) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id":             validForeignKey(factory, "service", sa.ServiceID),
+		"service_at_location_id": validForeignKey(factory, "service at location", sa.ServiceAtLocationID),
+	}
+}
+
+// ServiceAtLocationPatch contains the ServiceAtLocation fields to update; unset fields are left unchanged
+type ServiceAtLocationPatch struct {
+	ServiceID   Optional[string] `json:"service_id"`
+	LocationID  Optional[string] `json:"location_id"`
+	Description Optional[string] `json:"description"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ServiceAtLocationPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the service at location with the fields set in patch, validated with
+// the same rules as NewServiceAtLocation, and returns the JSON names of the changed fields
+func (sal *ServiceAtLocation) Apply(patch *ServiceAtLocationPatch) ([]string, error) {
+	return defaultFactory.Apply(sal, patch)
+}
+
+// validatePatch checks the fields of a patched ServiceAtLocation with the rules of its
+// constructor, keyed by JSON name
+func (sal *ServiceAtLocation) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id":  validForeignKey(factory, "service", &sal.ServiceID),
+		"location_id": validForeignKey(factory, "location", &sal.LocationID),
+	}
+}
+
+// LocationPatch contains the Location fields to update; unset fields are left unchanged
+type LocationPatch struct {
+	OrganizationID         Optional[string]                   `json:"organization_id"`
+	LocationType           Optional[LocationLocationTypeEnum] `json:"location_type"`
+	URL                    Optional[string]                   `json:"url"`
+	Name                   Optional[string]                   `json:"name"`
+	AlternateName          Optional[string]                   `json:"alternate_name"`
+	Description            Optional[string]                   `json:"description"`
+	Transportation         Optional[string]                   `json:"transportation"`
+	Latitude               Optional[float64]                  `json:"latitude"`
+	Longitude              Optional[float64]                  `json:"longitude"`
+	ExternalIdentifier     Optional[string]                   `json:"external_identifier"`
+	ExternalIdentifierType Optional[string]                   `json:"external_identifier_type"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p LocationPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the location with the fields set in patch, validated with
+// the same rules as NewLocation, and returns the JSON names of the changed fields
+func (l *Location) Apply(patch *LocationPatch) ([]string, error) {
+	return defaultFactory.Apply(l, patch)
+}
+
+// validatePatch checks the fields of a patched Location with the rules of its
+// constructor, keyed by JSON name
+func (l *Location) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id": validForeignKey(factory, "organization", l.OrganizationID),
+	}
+}
+
+// AddressPatch contains the Address fields to update; unset fields are left unchanged
+type AddressPatch struct {
+	LocationID    Optional[string]                   `json:"location_id"`
+	Attention     Optional[string]                   `json:"attention"`
+	Address1      Optional[string]                   `json:"address_1"`
+	Address2      Optional[string]                   `json:"address_2"`
+	City          Optional[string]                   `json:"city"`
+	Region        Optional[string]                   `json:"region"`
+	StateProvince Optional[string]                   `json:"state_province"`
+	PostalCode    Optional[string]                   `json:"postal_code"`
+	Country       Optional[string]                   `json:"country"`
+	AddressType   Optional[LocationLocationTypeEnum] `json:"address_type"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p AddressPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the address with the fields set in patch, validated with
+// the same rules as NewAddress, and returns the JSON names of the changed fields
+func (a *Address) Apply(patch *AddressPatch) ([]string, error) {
+	return defaultFactory.Apply(a, patch)
+}
+
+// validatePatch checks the fields of a patched Address with the rules of its
+// constructor, keyed by JSON name
+func (a *Address) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"location_id": validForeignKey(factory, "location", a.LocationID),
+		"country":     validCountry(a.Country),
+	}
+}
+
+// RequiredDocumentPatch contains the RequiredDocument fields to update; unset fields are left unchanged
+type RequiredDocumentPatch struct {
+	ServiceID Optional[string] `json:"service_id"`
+	Document  Optional[string] `json:"document"`
+	URI       Optional[string] `json:"uri"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p RequiredDocumentPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the required document with the fields set in patch, validated with
+// the same rules as NewRequiredDocument, and returns the JSON names of the changed fields
+func (rd *RequiredDocument) Apply(patch *RequiredDocumentPatch) ([]string, error) {
+	return defaultFactory.Apply(rd, patch)
+}
+
+// validatePatch checks the fields of a patched RequiredDocument with the rules of its
+// constructor, keyed by JSON name
+func (rd *RequiredDocument) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id": validForeignKey(factory, "service", rd.ServiceID),
+	}
+}
+
+// LanguagePatch contains the Language fields to update; unset fields are left unchanged
+type LanguagePatch struct {
+	ServiceID  Optional[string] `json:"service_id"`
+	LocationID Optional[string] `json:"location_id"`
+	PhoneID    Optional[string] `json:"phone_id"`
+	Name       Optional[string] `json:"name"`
+	Code       Optional[string] `json:"code"`
+	Note       Optional[string] `json:"note"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p LanguagePatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the language with the fields set in patch, validated with
+// the same rules as NewLanguage, and returns the JSON names of the changed fields
+func (l *Language) Apply(patch *LanguagePatch) ([]string, error) {
+	return defaultFactory.Apply(l, patch)
+}
+
+// validatePatch checks the fields of a patched Language with the rules of its
+// constructor, keyed by JSON name
+func (l *Language) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id":  validForeignKey(factory, "service", l.ServiceID),
+		"location_id": validForeignKey(factory, "location", l.LocationID),
+		"phone_id":    validForeignKey(factory, "phone", l.PhoneID),
+	}
+}
+
+// AccessibilityPatch contains the Accessibility fields to update; unset fields are left unchanged
+type AccessibilityPatch struct {
+	LocationID  Optional[string] `json:"location_id"`
+	Description Optional[string] `json:"description"`
+	Details     Optional[string] `json:"details"`
+	URL         Optional[string] `json:"url"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p AccessibilityPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the accessibility with the fields set in patch, validated with
+// the same rules as NewAccessibility, and returns the JSON names of the changed fields
+func (a *Accessibility) Apply(patch *AccessibilityPatch) ([]string, error) {
+	return defaultFactory.Apply(a, patch)
+}
+
+// validatePatch checks the fields of a patched Accessibility with the rules of its
+// constructor, keyed by JSON name
+func (a *Accessibility) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"location_id": validForeignKey(factory, "location", a.LocationID),
+	}
+}
+
+// AttributePatch contains the Attribute fields to update; unset fields are left unchanged
+type AttributePatch struct {
+	TaxonomyTermID Optional[string] `json:"taxonomy_term_id"`
+	LinkID         Optional[string] `json:"link_id"`
+	LinkType       Optional[string] `json:"link_type"`
+	LinkEntity     Optional[string] `json:"link_entity"`
+	Value          Optional[string] `json:"value"`
+	Label          Optional[string] `json:"label"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p AttributePatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the attribute with the fields set in patch, validated with
+// the same rules as NewAttribute, and returns the JSON names of the changed fields
+func (a *Attribute) Apply(patch *AttributePatch) ([]string, error) {
+	return defaultFactory.Apply(a, patch)
+}
+
+// validatePatch checks the fields of a patched Attribute with the rules of its
+// constructor, keyed by JSON name
+func (a *Attribute) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"taxonomy_term_id": validForeignKey(factory, "taxonomy term", &a.TaxonomyTermID),
+		"link_entity":      ValidateLinkEntity(a.LinkEntity),
+	}
+}
+
+// TaxonomyPatch contains the Taxonomy fields to update; unset fields are left unchanged
+type TaxonomyPatch struct {
+	Name        Optional[string] `json:"name"`
+	Description Optional[string] `json:"description"`
+	URI         Optional[string] `json:"uri"`
+	Version     Optional[string] `json:"version"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p TaxonomyPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the taxonomy with the fields set in patch, validated with
+// the same rules as NewTaxonomy, and returns the JSON names of the changed fields
+func (t *Taxonomy) Apply(patch *TaxonomyPatch) ([]string, error) {
+	return defaultFactory.Apply(t, patch)
+}
+
+// TaxonomyTermPatch contains the TaxonomyTerm fields to update; unset fields are left unchanged
+type TaxonomyTermPatch struct {
+	TaxonomyID  Optional[string] `json:"taxonomy_id"`
+	ParentID    Optional[string] `json:"parent_id"`
+	Code        Optional[string] `json:"code"`
+	Name        Optional[string] `json:"name"`
+	Description Optional[string] `json:"description"`
+	TaxonomyStr Optional[string] `json:"taxonomy"`
+	Language    Optional[string] `json:"language"`
+	TermURI     Optional[string] `json:"term_uri"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p TaxonomyTermPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the taxonomy term with the fields set in patch, validated with
+// the same rules as NewTaxonomyTerm, and returns the JSON names of the changed fields
+func (tt *TaxonomyTerm) Apply(patch *TaxonomyTermPatch) ([]string, error) {
+	return defaultFactory.Apply(tt, patch)
+}
+
+// validatePatch checks the fields of a patched TaxonomyTerm with the rules of its
+// constructor, keyed by JSON name
+func (tt *TaxonomyTerm) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"taxonomy_id": validForeignKey(factory, "taxonomy", tt.TaxonomyID),
+		"parent_id":   validForeignKey(factory, "parent", tt.ParentID),
+	}
+}
+
+// ContactPatch contains the Contact fields to update; unset fields are left unchanged
+type ContactPatch struct {
+	OrganizationID      Optional[string] `json:"organization_id"`
+	ServiceID           Optional[string] `json:"service_id"`
+	ServiceAtLocationID Optional[string] `json:"service_at_location_id"`
+	LocationID          Optional[string] `json:"location_id"`
+	Name                Optional[string] `json:"name"`
+	Title               Optional[string] `json:"title"`
+	Department          Optional[string] `json:"department"`
+	Email               Optional[string] `json:"email"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ContactPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the contact with the fields set in patch, validated with
+// the same rules as NewContact, and returns the JSON names of the changed fields
+func (c *Contact) Apply(patch *ContactPatch) ([]string, error) {
+	return defaultFactory.Apply(c, patch)
+}
+
+// validatePatch checks the fields of a patched Contact with the rules of its
+// constructor, keyed by JSON name
+func (c *Contact) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"organization_id":        validForeignKey(factory, "organization", c.OrganizationID),
+		"service_id":             validForeignKey(factory, "service", c.ServiceID),
+		"service_at_location_id": validForeignKey(factory, "service at location", c.ServiceAtLocationID),
+		"location_id":            validForeignKey(factory, "location", c.LocationID),
+	}
+}
+
+// PhonePatch contains the Phone fields to update; unset fields are left unchanged
+type PhonePatch struct {
+	LocationID          Optional[string]         `json:"location_id"`
+	ServiceID           Optional[string]         `json:"service_id"`
+	OrganizationID      Optional[string]         `json:"organization_id"`
+	ContactID           Optional[string]         `json:"contact_id"`
+	ServiceAtLocationID Optional[string]         `json:"service_at_location_id"`
+	Number              Optional[string]         `json:"number"`
+	Extension           Optional[PhoneExtension] `json:"extension"`
+	Type                Optional[string]         `json:"type"`
+	Description         Optional[string]         `json:"description"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p PhonePatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the phone with the fields set in patch, validated with
+// the same rules as NewPhone, and returns the JSON names of the changed fields
+func (p *Phone) Apply(patch *PhonePatch) ([]string, error) {
+	return defaultFactory.Apply(p, patch)
+}
+
+// validatePatch checks the fields of a patched Phone with the rules of its
+// constructor, keyed by JSON name
+func (p *Phone) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"location_id":            validForeignKey(factory, "location", p.LocationID),
+		"service_id":             validForeignKey(factory, "service", p.ServiceID),
+		"organization_id":        validForeignKey(factory, "organization", p.OrganizationID),
+		"contact_id":             validForeignKey(factory, "contact", p.ContactID),
+		"service_at_location_id": validForeignKey(factory, "service at location", p.ServiceAtLocationID),
+	}
+}
+
+// SchedulePatch contains the Schedule fields to update; unset fields are left unchanged
+type SchedulePatch struct {
+	ServiceID           Optional[string]           `json:"service_id"`
+	LocationID          Optional[string]           `json:"location_id"`
+	ServiceAtLocationID Optional[string]           `json:"service_at_location_id"`
+	ValidFrom           Optional[FlexDate]         `json:"valid_from"`
+	ValidTo             Optional[FlexDate]         `json:"valid_to"`
+	DTStart             Optional[FlexDate]         `json:"dtstart"`
+	Timezone            Optional[float64]          `json:"timezone"`
+	Until               Optional[FlexDate]         `json:"until"`
+	Count               Optional[int]              `json:"count"`
+	Wkst                Optional[ScheduleWkstEnum] `json:"wkst"`
+	Freq                Optional[ScheduleFreqEnum] `json:"freq"`
+	Interval            Optional[int]              `json:"interval"`
+	Byday               Optional[string]           `json:"byday"`
+	Byweekno            Optional[string]           `json:"byweekno"`
+	Bymonthday          Optional[string]           `json:"bymonthday"`
+	Byyearday           Optional[string]           `json:"byyearday"`
+	Description         Optional[string]           `json:"description"`
+	OpensAt             Optional[FlexClock]        `json:"opens_at"`
+	ClosesAt            Optional[FlexClock]        `json:"closes_at"`
+	ScheduleLink        Optional[string]           `json:"schedule_link"`
+	AttendingType       Optional[string]           `json:"attending_type"`
+	Notes               Optional[string]           `json:"notes"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p SchedulePatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the schedule with the fields set in patch, validated with
+// the same rules as NewSchedule, and returns the JSON names of the changed fields
+func (s *Schedule) Apply(patch *SchedulePatch) ([]string, error) {
+	return defaultFactory.Apply(s, patch)
+}
+
+// validatePatch checks the fields of a patched Schedule with the rules of its
+// constructor, keyed by JSON name
+func (s *Schedule) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id":             validForeignKey(factory, "service", s.ServiceID),
+		"location_id":            validForeignKey(factory, "location", s.LocationID),
+		"service_at_location_id": validForeignKey(factory, "service at location", s.ServiceAtLocationID),
+	}
+}
+
+// ServiceCapacityPatch contains the ServiceCapacity fields to update; unset fields are left unchanged
+type ServiceCapacityPatch struct {
+	ServiceID   Optional[string]   `json:"service_id"`
+	UnitID      Optional[string]   `json:"unit_id"`
+	Available   Optional[float64]  `json:"available"`
+	Maximum     Optional[float64]  `json:"maximum"`
+	Description Optional[string]   `json:"description"`
+	Updated     Optional[FlexTime] `json:"updated"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p ServiceCapacityPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the service capacity with the fields set in patch, validated with
+// the same rules as NewServiceCapacity, and returns the JSON names of the changed fields
+func (sc *ServiceCapacity) Apply(patch *ServiceCapacityPatch) ([]string, error) {
+	return defaultFactory.Apply(sc, patch)
+}
+
+// validatePatch checks the fields of a patched ServiceCapacity with the rules of its
+// constructor, keyed by JSON name
+func (sc *ServiceCapacity) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id": validForeignKey(factory, "service", &sc.ServiceID),
+		"unit_id":    validForeignKey(factory, "unit", &sc.UnitID),
+	}
+}
+
+// CostOptionPatch contains the CostOption fields to update; unset fields are left unchanged
+type CostOptionPatch struct {
+	ServiceID         Optional[string]   `json:"service_id"`
+	ValidFrom         Optional[FlexDate] `json:"valid_from"`
+	ValidTo           Optional[FlexDate] `json:"valid_to"`
+	Option            Optional[string]   `json:"option"`
+	Currency          Optional[string]   `json:"currency"`
+	Amount            Optional[float64]  `json:"amount"`
+	AmountDescription Optional[string]   `json:"amount_description"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p CostOptionPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the cost option with the fields set in patch, validated with
+// the same rules as NewCostOption, and returns the JSON names of the changed fields
+func (co *CostOption) Apply(patch *CostOptionPatch) ([]string, error) {
+	return defaultFactory.Apply(co, patch)
+}
+
+// validatePatch checks the fields of a patched CostOption with the rules of its
+// constructor, keyed by JSON name
+func (co *CostOption) validatePatch(factory *Factory) map[string]error {
+	return map[string]error{
+		"service_id": validForeignKey(factory, "service", &co.ServiceID),
+	}
+}
+
+// MetaTableDescriptionPatch contains the MetaTableDescription fields to update; unset fields are left unchanged
+type MetaTableDescriptionPatch struct {
+	Name         Optional[string] `json:"name"`
+	Language     Optional[string] `json:"language"`
+	CharacterSet Optional[string] `json:"character_set"`
+}
+
+// MarshalJSON encodes the fields set in the patch, omitting unset fields
+func (p MetaTableDescriptionPatch) MarshalJSON() ([]byte, error) {
+	return marshalPatch(p)
+}
+
+// Apply updates the meta table description with the fields set in patch, validated with
+// the same rules as NewMetaTableDescription, and returns the JSON names of the changed fields
+func (mtd *MetaTableDescription) Apply(patch *MetaTableDescriptionPatch) ([]string, error) {
+	return defaultFactory.Apply(mtd, patch)
+}
+
+// patchValidator is implemented by records whose patched values need checks
+// beyond required fields; validatePatch returns the result of each check by
+// JSON field name
+type patchValidator interface {
+	validatePatch(factory *Factory) map[string]error
+}
+
+// Apply updates record, a pointer to an HSDS record, with the fields set in
+// patch, a pointer to the record's patch type, like the record's own Apply
+// method, but validates foreign keys with the factory's UUID rules and stamps
+// UpdatedAt with the factory's clock
+func (f *Factory) Apply(record, patch any) ([]string, error) {
+	vr, vp := reflect.ValueOf(record), reflect.ValueOf(patch)
+	if vr.Kind() != reflect.Pointer || vr.Type().Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not a pointer to an HSDS record", record)
+	}
+	if vr.IsNil() {
+		return nil, fmt.Errorf("record is required")
+	}
+	if vp.Kind() != reflect.Pointer || vp.Type().Elem().Name() != vr.Type().Elem().Name()+"Patch" {
+		return nil, fmt.Errorf("%T is not a patch for %T", patch, record)
+	}
+	if vp.IsNil() {
+		return nil, nil
+	}
+
+	updated := reflect.New(vr.Type().Elem())
+	updated.Elem().Set(vr.Elem())
+	changed, err := applyPatch(updated.Elem(), vp.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	// only the changed fields are checked, so a stored value that predates
+	// the rules does not block unrelated updates
+	if v, ok := updated.Interface().(patchValidator); ok {
+		checks := v.validatePatch(f)
+		errs := make([]error, 0, len(changed))
+		for _, name := range changed {
+			errs = append(errs, checks[name])
+		}
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	updated.Elem().FieldByName("UpdatedAt").Set(reflect.ValueOf(FlexTime{f.now()}))
+	vr.Elem().Set(updated.Elem())
+	return changed, nil
+}
+
+// applyPatch copies the set fields of the patch vp onto the record vr and
+// returns the JSON names of the fields whose values changed
+func applyPatch(vr, vp reflect.Value) ([]string, error) {
+	var changed []string
+	for i := 0; i < vp.NumField(); i++ {
+		opt := vp.Field(i)
+		if !opt.FieldByName("Set").Bool() {
+			continue
+		}
+		name := vp.Type().Field(i).Name
+		field := vr.FieldByName(name)
+		sf, _ := vr.Type().FieldByName(name)
+
+		previous := reflect.New(field.Type()).Elem()
+		previous.Set(field)
+
+		switch {
+		case opt.FieldByName("Null").Bool() && field.Kind() == reflect.Pointer:
+			field.Set(reflect.Zero(field.Type()))
+		case opt.FieldByName("Null").Bool():
+			return nil, fmt.Errorf("%s is required and cannot be null", jsonName(sf))
+		case field.Kind() == reflect.Pointer:
+			value := reflect.New(field.Type().Elem())
+			value.Elem().Set(opt.FieldByName("Value"))
+			field.Set(value)
+		default:
+			field.Set(opt.FieldByName("Value"))
+		}
+
+		if !fieldValuesEqual(previous, field) {
+			changed = append(changed, jsonName(sf))
+		}
+	}
+	return changed, nil
+}
+
+// marshalPatch encodes the set fields of a patch struct as a JSON object,
+// leaving unset fields out so that decoding the result leaves them unset
+func marshalPatch(patch any) ([]byte, error) {
+	v := reflect.ValueOf(patch)
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).FieldByName("Set").Bool() {
+			continue
+		}
+		value, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		key, _ := json.Marshal(jsonName(v.Type().Field(i)))
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// validForeignKey checks an optional foreign key with the factory's UUID rules
+func validForeignKey(factory *Factory, name string, id *string) error {
+	if id != nil && !factory.validUUID(*id) {
+		return fmt.Errorf("invalid %s ID format: must be a UUID", name)
+	}
+	return nil
+}
+
+// validCountry checks that country is an ISO 3166-1 alpha-2 code
+func validCountry(country string) error {
+	if len(country) != 2 {
+		return fmt.Errorf("country must be a 2-letter code")
+	}
+	if !IsValidCountryCode(country) {
+		return fmt.Errorf("country must be an ISO 3166-1 alpha-2 code")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestPatchJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    OrganizationPatch
+		wantJSON string
+		wantName string
+		wantDesc string
+		wantMail *string
+		changed  []string
+	}{
+		{
+			name:     "set one field",
+			patch:    OrganizationPatch{Name: Some("New")},
+			wantJSON: `{"name":"New"}`,
+			wantName: "New", wantDesc: "Groceries", wantMail: strPtr("info@example.org"),
+			changed: []string{"name"},
+		},
+		{
+			name:     "clear an optional field",
+			patch:    OrganizationPatch{Email: Null[string]()},
+			wantJSON: `{"email":null}`,
+			wantName: "Old", wantDesc: "Groceries", wantMail: nil,
+			changed: []string{"email"},
+		},
+		{
+			name:     "empty patch",
+			patch:    OrganizationPatch{},
+			wantJSON: `{}`,
+			wantName: "Old", wantDesc: "Groceries", wantMail: strPtr("info@example.org"),
+		},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.patch)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(data) != tt.wantJSON {
+			t.Errorf("%s: JSON = %s, want %s", tt.name, data, tt.wantJSON)
+		}
+
+		var decoded OrganizationPatch
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		org := Organization{ID: "o1", Name: "Old", Description: "Groceries", Email: strPtr("info@example.org")}
+		changed, err := org.Apply(&decoded)
+		if err != nil {
+			t.Fatalf("%s: Apply: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(changed, tt.changed) {
+			t.Errorf("%s: changed = %v, want %v", tt.name, changed, tt.changed)
+		}
+		if org.Name != tt.wantName || org.Description != tt.wantDesc || !reflect.DeepEqual(org.Email, tt.wantMail) {
+			t.Errorf("%s: organization = %q %q %v", tt.name, org.Name, org.Description, org.Email)
+		}
+		if (len(tt.changed) > 0) == org.UpdatedAt.IsZero() {
+			t.Errorf("%s: UpdatedAt = %v with %d changes", tt.name, org.UpdatedAt, len(tt.changed))
+		}
+	}
+}
+
+func TestPatchApplyRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch *OrganizationPatch
+	}{
+		{"null required field", &OrganizationPatch{Name: Null[string]()}},
+		{"invalid foreign key", &OrganizationPatch{Name: Some("New"), ParentOrganizationID: Some("not-a-uuid")}},
+	}
+	for _, tt := range tests {
+		org := Organization{ID: "o1", Name: "Old"}
+		if _, err := org.Apply(tt.patch); err == nil {
+			t.Errorf("%s: Apply succeeded", tt.name)
+		}
+		if org.Name != "Old" || org.ParentOrganizationID != nil || !org.UpdatedAt.IsZero() {
+			t.Errorf("%s: failed Apply modified the record: %+v", tt.name, org)
+		}
+	}
+}
+
+func TestPatchApplyOnlyValidatesChangedFields(t *testing.T) {
+	v1 := "00000000-0000-1000-8000-000000000001"
+	tests := []struct {
+		name    string
+		apply   func() error
+		wantErr bool
+	}{
+		{"legacy foreign key, unrelated field", func() error {
+			svc := Service{ID: "s1", OrganizationID: v1, Name: "Old"}
+			_, err := svc.Apply(&ServicePatch{Name: Some("New")})
+			return err
+		}, false},
+		{"legacy country, unrelated field", func() error {
+			addr := Address{ID: "a1", Address1: "1 Main St", Country: "USA"}
+			_, err := addr.Apply(&AddressPatch{City: Some("Seattle")})
+			return err
+		}, false},
+		{"invalid foreign key set by the patch", func() error {
+			svc := Service{ID: "s1", OrganizationID: testID(1), Name: "Old"}
+			_, err := svc.Apply(&ServicePatch{OrganizationID: Some(v1)})
+			return err
+		}, true},
+		{"invalid country set by the patch", func() error {
+			addr := Address{ID: "a1", Address1: "1 Main St", Country: "US"}
+			_, err := addr.Apply(&AddressPatch{Country: Some("USA")})
+			return err
+		}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.apply(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Apply error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFactoryApply(t *testing.T) {
+	f := newTestFactory()
+	svc := Service{ID: "s1", OrganizationID: "00000000-0000-4000-8000-0000000000aa", Name: "Old"}
+	changed, err := f.Apply(&svc, &ServicePatch{Name: Some("New")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || svc.Name != "New" || !svc.UpdatedAt.Equal(testTime) {
+		t.Errorf("service = %q updated %v, changed %v", svc.Name, svc.UpdatedAt, changed)
+	}
+
+	if _, err := f.Apply(&svc, &OrganizationPatch{Name: Some("New")}); err == nil {
+		t.Error("Apply accepted an organization patch for a service")
+	}
+	if changed, err := f.Apply(&svc, (*ServicePatch)(nil)); err != nil || changed != nil {
+		t.Errorf("nil patch = %v, %v; want no changes", changed, err)
+	}
+}
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	var patch SchedulePatch
+	data := `{"description":null,"opens_at":"2024-01-01T09:30:00Z","count":3}`
+	if err := json.Unmarshal([]byte(data), &patch); err != nil {
+		t.Fatal(err)
+	}
+	if !patch.Description.Set || !patch.Description.Null {
+		t.Errorf("description = %+v, want set to null", patch.Description)
+	}
+	if v, ok := patch.OpensAt.Get(); !ok || v.String() != "09:30:00" {
+		t.Errorf("opens_at = %v, %v; want 09:30:00", v, ok)
+	}
+	if v, ok := patch.Count.Get(); !ok || v != 3 {
+		t.Errorf("count = %v, %v; want 3", v, ok)
+	}
+	if patch.Notes.Set {
+		t.Error("absent notes decoded as set")
+	}
+}
@@ -0,0 +1,308 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	phoneExtensionPattern = regexp.MustCompile(`(?i)\s*(?:,|;|#|x|ext\.?|extn\.?|extension)\s*(\d{1,10})\s*$`)
+	nanpAreaCodePattern   = regexp.MustCompile(`^[2-9][0-8]\d$`)
+	nanpExchangePattern   = regexp.MustCompile(`^[2-9]\d\d$`)
+	trailingNotePattern   = regexp.MustCompile(`\s*\([^()0-9]*\)\s*$`)
+)
+
+// nanpRegions lists the ISO 3166-1 regions that share the +1 country calling code
+var nanpRegions = makeSet(
+	"US", "CA", "AG", "AI", "AS", "BB", "BM", "BS", "DM", "DO", "GD", "GU", "JM", "KN",
+	"KY", "LC", "MP", "MS", "PR", "SX", "TC", "TT", "VC", "VG", "VI",
+)
+
+// nanpShortCodes lists the N11 and crisis line short codes dialable across the NANP
+var nanpShortCodes = makeSet("211", "311", "411", "511", "611", "711", "811", "911", "988")
+
+// letterDigits maps vanity number letters to their keypad digit
+var letterDigits = strings.NewReplacer(
+	"A", "2", "B", "2", "C", "2", "D", "3", "E", "3", "F", "3",
+	"G", "4", "H", "4", "I", "4", "J", "5", "K", "5", "L", "5",
+	"M", "6", "N", "6", "O", "6", "P", "7", "Q", "7", "R", "7", "S", "7",
+	"T", "8", "U", "8", "V", "8", "W", "9", "X", "9", "Y", "9", "Z", "9",
+)
+
+// phoneTypeWords maps whole words in a number's labels or description to a
+// phone type, in order of precedence
+var phoneTypeWords = []struct {
+	phoneType PhoneTypeEnum
+	words     map[string]bool
+}{
+	{PhoneTypeTextphone, makeSet("tty", "tdd", "textphone", "teletype", "teletypewriter")},
+	{PhoneTypeFax, makeSet("fax", "facsimile")},
+	{PhoneTypeText, makeSet("sms", "text", "texting", "txt")},
+	{PhoneTypeVideo, makeSet("video", "videophone", "vrs", "vp")},
+	{PhoneTypePager, makeSet("pager", "beeper")},
+	{PhoneTypeCell, makeSet("cell", "cellular", "cellphone", "mobile")},
+}
+
+// phoneLabelWords are the words that may label a number rather than spell
+// part of a vanity number; trailing labels are stripped before parsing
+var phoneLabelWords = makeSet(
+	"tty", "tdd", "textphone", "fax", "facsimile", "sms", "text", "txt", "video", "vrs",
+	"pager", "cell", "mobile", "voice", "phone", "tel", "main", "office", "line",
+	"only", "hotline", "direct", "toll", "free", "number", "or",
+)
+
+// NormalizedPhone is the canonical form of a raw phone number
+type NormalizedPhone struct {
+	// Number is the E.164 form ("+12065551212"), or the bare digits for short codes
+	Number string
+	// Extension keeps any leading zeros ("0042")
+	Extension string
+	// ShortCode is true for N11 and crisis line codes such as 211, 911 and 988
+	ShortCode bool
+	// Type is inferred from labels in the raw input, defaulting to voice
+	Type PhoneTypeEnum
+}
+
+// NormalizePhone parses a free-text phone number such as "(206) 555 1212 ext. 104"
+// into E.164 form. defaultRegion is the ISO 3166-1 alpha-2 code used for numbers
+// written without a country code; only NANP regions can be dialed nationally,
+// other numbers must be written in international form (+44..., 00 44..., 011 44...).
+func NormalizePhone(raw, defaultRegion string) (*NormalizedPhone, error) {
+	region := strings.ToUpper(strings.TrimSpace(defaultRegion))
+	result := &NormalizedPhone{Type: InferPhoneType(raw, "")}
+
+	number := stripPhoneLabels(raw)
+	if match := phoneExtensionPattern.FindStringSubmatchIndex(number); match != nil {
+		result.Extension = number[match[2]:match[3]]
+		number = number[:match[0]]
+	}
+
+	international := strings.HasPrefix(strings.TrimSpace(number), "+")
+	digits := phoneDigits(number)
+	if digits == "" {
+		return nil, fmt.Errorf("phone number %q contains no digits", raw)
+	}
+
+	if !international && nanpShortCodes[digits] {
+		result.Number = digits
+		result.ShortCode = true
+		if digits == "711" {
+			result.Type = PhoneTypeTextphone
+		}
+		return result, nil
+	}
+
+	switch {
+	case strings.HasPrefix(digits, "011") && nanpRegions[region] && !international:
+		digits = digits[3:]
+		international = true
+	case strings.HasPrefix(digits, "00") && !international:
+		digits = digits[2:]
+		international = true
+	}
+
+	if international {
+		if strings.HasPrefix(digits, "1") {
+			if err := validateNANP(digits[1:]); err != nil {
+				return nil, fmt.Errorf("invalid phone number %q: %w", raw, err)
+			}
+		} else if len(digits) < 8 || len(digits) > 15 {
+			return nil, fmt.Errorf("invalid phone number %q: international numbers must have 8 to 15 digits", raw)
+		}
+		result.Number = "+" + digits
+		return result, nil
+	}
+
+	if !nanpRegions[region] {
+		return nil, fmt.Errorf("invalid phone number %q: national numbers are only supported for NANP regions, got %q", raw, defaultRegion)
+	}
+	if len(digits) == 11 && digits[0] == '1' {
+		digits = digits[1:]
+	}
+	if err := validateNANP(digits); err != nil {
+		return nil, fmt.Errorf("invalid phone number %q: %w", raw, err)
+	}
+
+	result.Number = "+1" + digits
+	return result, nil
+}
+
+// InferPhoneType guesses the HSDS phone type from whole-word labels in the
+// number or its description, e.g. "Fax: 206-555-1212" or "TTY line"
+func InferPhoneType(raw, description string) PhoneTypeEnum {
+	words := phoneWords(raw + " " + description)
+	for _, candidate := range phoneTypeWords {
+		for _, w := range words {
+			if candidate.words[w] {
+				return candidate.phoneType
+			}
+		}
+	}
+	return PhoneTypeVoice
+}
+
+// Normalize rewrites Number to E.164 and moves any extension found in it,
+// leading zeros included, into Extension. Type is filled in when empty.
+func (p *Phone) Normalize(defaultRegion string) error {
+	normalized, err := NormalizePhone(p.Number, defaultRegion)
+	if err != nil {
+		return err
+	}
+
+	p.Number = normalized.Number
+	if normalized.Extension != "" {
+		ext := PhoneExtension(normalized.Extension)
+		p.Extension = &ext
+	}
+	if p.Type == nil || *p.Type == "" {
+		phoneType := normalized.Type
+		if phoneType == PhoneTypeVoice && p.Description != nil {
+			phoneType = InferPhoneType("", *p.Description)
+		}
+		typeStr := string(phoneType)
+		p.Type = &typeStr
+	}
+
+	return nil
+}
+
+// validateNANP checks a ten-digit national number against NANP numbering rules
+func validateNANP(digits string) error {
+	if len(digits) != 10 {
+		return fmt.Errorf("NANP numbers must have 10 digits, got %d", len(digits))
+	}
+	area, exchange := digits[:3], digits[3:6]
+	if !nanpAreaCodePattern.MatchString(area) || area[1:] == "11" {
+		return fmt.Errorf("invalid area code %s", area)
+	}
+	if !nanpExchangePattern.MatchString(exchange) || exchange[1:] == "11" {
+		return fmt.Errorf("invalid exchange %s", exchange)
+	}
+	return nil
+}
+
+// stripPhoneLabels removes leading labels such as "Fax:" or "TTY -",
+// trailing label words such as "FAX" and trailing notes in parentheses such
+// as "(TTY only)" or "(Spanish)" from a number, keeping vanity letters
+// ("1-800-FLOWERS")
+func stripPhoneLabels(raw string) string {
+	if i := strings.IndexAny(raw, "+(0123456789"); i > 0 {
+		raw = raw[i:]
+	}
+	for {
+		stripped := trailingNotePattern.ReplaceAllString(raw, "")
+		fields := strings.Fields(stripped)
+		for len(fields) > 1 && isPhoneLabel(fields[len(fields)-1]) {
+			fields = fields[:len(fields)-1]
+		}
+		stripped = strings.Join(fields, " ")
+		if stripped == raw || stripped == "" {
+			return stripped
+		}
+		raw = stripped
+	}
+}
+
+// isPhoneLabel reports whether a whitespace-separated token is made only of
+// label words, e.g. "FAX", "(TTY/TDD)" or "line:"
+func isPhoneLabel(token string) bool {
+	words := phoneWords(token)
+	if len(words) == 0 || strings.ContainsAny(token, "0123456789") {
+		return false
+	}
+	for _, w := range words {
+		if !phoneLabelWords[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// phoneWords splits text into lower-case words of letters and digits
+func phoneWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// phoneDigits keeps only digits, translating upper-case vanity words
+// ("1-800-FLOWERS", "1-800-GO-FEDEX") to keypad digits; other words are dropped
+func phoneDigits(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] >= '0' && s[i] <= '9' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && s[j] >= 'A' && s[j] <= 'Z' {
+			j++
+		}
+		if j == i {
+			i++
+			continue
+		}
+		whole := (i == 0 || !unicode.IsLetter(rune(s[i-1]))) && (j == len(s) || !unicode.IsLetter(rune(s[j])))
+		if word := s[i:j]; whole && isVanityWord(word, b.String()) {
+			b.WriteString(letterDigits.Replace(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// isVanityWord reports whether an upper-case word following digits spells
+// part of the number: any word after a toll-free 8XX prefix, or a word of 4
+// to 7 letters while the national number is still incomplete
+func isVanityWord(word, digits string) bool {
+	national := strings.TrimPrefix(digits, "1")
+	if len(national) >= 10 {
+		return false
+	}
+	if len(national) == 3 && national[0] == '8' && national[1] == national[2] {
+		return true
+	}
+	return len(word) >= 4 && len(word) <= 7
+}
+
+// PhoneExtension is a phone extension kept as written so leading zeros
+// survive. It reads JSON numbers or strings and writes a number unless that
+// would drop a leading zero.
+type PhoneExtension string
+
+// MarshalJSON writes the extension as a number when it has no leading zero
+func (e PhoneExtension) MarshalJSON() ([]byte, error) {
+	s := string(e)
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil && (s == "0" || s[0] != '0') {
+		return []byte(s), nil
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON reads a number or a string; null leaves the value unchanged
+func (e *PhoneExtension) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*e = PhoneExtension(strings.TrimSpace(s))
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid phone extension %s", data)
+	}
+	if f, err := n.Float64(); err == nil && f == math.Trunc(f) && f >= 0 {
+		*e = PhoneExtension(strconv.FormatFloat(f, 'f', -1, 64))
+		return nil
+	}
+	*e = PhoneExtension(n.String())
+	return nil
+}
@@ -0,0 +1,127 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw       string
+		region    string
+		number    string
+		extension string
+		phoneType PhoneTypeEnum
+		shortCode bool
+		wantErr   bool
+	}{
+		{raw: "(206) 555-1212", region: "US", number: "+12065551212", phoneType: PhoneTypeVoice},
+		{raw: "1-206-555-1212 ext. 104", region: "US", number: "+12065551212", extension: "104", phoneType: PhoneTypeVoice},
+		{raw: "206.555.1212 x0042", region: "US", number: "+12065551212", extension: "0042", phoneType: PhoneTypeVoice},
+		{raw: "Fax: 206-555-1212", region: "US", number: "+12065551212", phoneType: PhoneTypeFax},
+		{raw: "206-555-1212 FAX", region: "US", number: "+12065551212", phoneType: PhoneTypeFax},
+		{raw: "206-555-1212 (TTY/TDD only)", region: "US", number: "+12065551212", phoneType: PhoneTypeTextphone},
+		{raw: "206-555-1212 ext 5 (fax)", region: "US", number: "+12065551212", extension: "5", phoneType: PhoneTypeFax},
+		{raw: "1-800-FLOWERS", region: "US", number: "+18003569377", phoneType: PhoneTypeVoice},
+		{raw: "1 800 FLOWERS", region: "US", number: "+18003569377", phoneType: PhoneTypeVoice},
+		{raw: "1-800-GO-FEDEX", region: "US", number: "+18004633339", phoneType: PhoneTypeVoice},
+		{raw: "206-555-1212 (Spanish)", region: "US", number: "+12065551212", phoneType: PhoneTypeVoice},
+		{raw: "206-555-1212 (Se habla español) FAX", region: "US", number: "+12065551212", phoneType: PhoneTypeFax},
+		{raw: "206-555-1212 SPANISH", region: "US", number: "+12065551212", phoneType: PhoneTypeVoice},
+		{raw: "206-555-1212 x12 (after hours)", region: "US", number: "+12065551212", extension: "12", phoneType: PhoneTypeVoice},
+		{raw: "+44 20 7946 0958", region: "US", number: "+442079460958", phoneType: PhoneTypeVoice},
+		{raw: "011 44 20 7946 0958", region: "US", number: "+442079460958", phoneType: PhoneTypeVoice},
+		{raw: "211", region: "US", number: "211", phoneType: PhoneTypeVoice, shortCode: true},
+		{raw: "711", region: "US", number: "711", phoneType: PhoneTypeTextphone, shortCode: true},
+		{raw: "020 7946 0958", region: "GB", wantErr: true},
+		{raw: "206-155-1212", region: "US", wantErr: true},
+		{raw: "call us", region: "US", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.raw, tt.region)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NormalizePhone(%q) = %+v, want an error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NormalizePhone(%q): %v", tt.raw, err)
+			continue
+		}
+		if got.Number != tt.number || got.Extension != tt.extension || got.Type != tt.phoneType || got.ShortCode != tt.shortCode {
+			t.Errorf("NormalizePhone(%q) = %+v, want %s ext %q %s short %v", tt.raw, got, tt.number, tt.extension, tt.phoneType, tt.shortCode)
+		}
+	}
+}
+
+func TestInferPhoneType(t *testing.T) {
+	tests := []struct {
+		raw, description string
+		want             PhoneTypeEnum
+	}{
+		{"206-555-1212", "Fairfax County office", PhoneTypeVoice},
+		{"206-555-1212", "Fax line", PhoneTypeFax},
+		{"TTY: 206-555-1212", "", PhoneTypeTextphone},
+		{"206-555-1212", "Text us", PhoneTypeText},
+		{"206-555-1212", "Textphone users", PhoneTypeTextphone},
+		{"206-555-1212", "Context and help", PhoneTypeVoice},
+		{"206-555-1212", "Excellent service", PhoneTypeVoice},
+		{"206-555-1212", "Mobile outreach van", PhoneTypeCell},
+		{"206-555-1212", "", PhoneTypeVoice},
+	}
+	for _, tt := range tests {
+		if got := InferPhoneType(tt.raw, tt.description); got != tt.want {
+			t.Errorf("InferPhoneType(%q, %q) = %s, want %s", tt.raw, tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestPhoneNormalize(t *testing.T) {
+	desc := "Fairfax County main line"
+	p := Phone{Number: "(206) 555-1212 ext. 007", Description: &desc}
+	if err := p.Normalize("US"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Number != "+12065551212" {
+		t.Errorf("number = %s", p.Number)
+	}
+	if p.Extension == nil || *p.Extension != "007" {
+		t.Errorf("extension = %v, want 007", p.Extension)
+	}
+	if p.Type == nil || *p.Type != string(PhoneTypeVoice) {
+		t.Errorf("type = %v, want voice", p.Type)
+	}
+}
+
+func TestPhoneExtensionJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PhoneExtension
+		out  string
+	}{
+		{`104`, "104", `104`},
+		{`104.0`, "104", `104`},
+		{`"0042"`, "0042", `"0042"`},
+		{`" 12 "`, "12", `12`},
+		{`"12a"`, "12a", `"12a"`},
+	}
+	for _, tt := range tests {
+		var ext PhoneExtension
+		if err := json.Unmarshal([]byte(tt.in), &ext); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if ext != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.in, ext, tt.want)
+		}
+		data, err := json.Marshal(ext)
+		if err != nil || string(data) != tt.out {
+			t.Errorf("Marshal(%q) = %s, %v; want %s", ext, data, err, tt.out)
+		}
+	}
+	var ext PhoneExtension
+	if err := json.Unmarshal([]byte(`true`), &ext); err == nil {
+		t.Error("Unmarshal accepted a boolean extension")
+	}
+}
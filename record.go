@@ -0,0 +1,79 @@
+package hsds_types
+
+// Record is an HSDS record identified by a string ID. Every table type of a
+// Dataset implements it.
+type Record interface {
+	RecordID() string
+}
+
+// RecordID returns the Organization ID
+func (o Organization) RecordID() string { return o.ID }
+
+// RecordID returns the OrganizationIdentifier ID
+func (o OrganizationIdentifier) RecordID() string { return o.ID }
+
+// RecordID returns the URL ID
+func (u URL) RecordID() string { return u.ID }
+
+// RecordID returns the Funding ID
+func (f Funding) RecordID() string { return f.ID }
+
+// RecordID returns the Unit ID
+func (u Unit) RecordID() string { return u.ID }
+
+// RecordID returns the Program ID
+func (p Program) RecordID() string { return p.ID }
+
+// RecordID returns the Service ID
+func (s Service) RecordID() string { return s.ID }
+
+// RecordID returns the ServiceArea ID
+func (s ServiceArea) RecordID() string { return s.ID }
+
+// RecordID returns the ServiceAtLocation ID
+func (s ServiceAtLocation) RecordID() string { return s.ID }
+
+// RecordID returns the Location ID
+func (l Location) RecordID() string { return l.ID }
+
+// RecordID returns the Address ID
+func (a Address) RecordID() string { return a.ID }
+
+// RecordID returns the RequiredDocument ID
+func (r RequiredDocument) RecordID() string { return r.ID }
+
+// RecordID returns the Language ID
+func (l Language) RecordID() string { return l.ID }
+
+// RecordID returns the Accessibility ID
+func (a Accessibility) RecordID() string { return a.ID }
+
+// RecordID returns the Attribute ID
+func (a Attribute) RecordID() string { return a.ID }
+
+// RecordID returns the Taxonomy ID
+func (t Taxonomy) RecordID() string { return t.ID }
+
+// RecordID returns the TaxonomyTerm ID
+func (t TaxonomyTerm) RecordID() string { return t.ID }
+
+// RecordID returns the Contact ID
+func (c Contact) RecordID() string { return c.ID }
+
+// RecordID returns the Phone ID
+func (p Phone) RecordID() string { return p.ID }
+
+// RecordID returns the Schedule ID
+func (s Schedule) RecordID() string { return s.ID }
+
+// RecordID returns the ServiceCapacity ID
+func (s ServiceCapacity) RecordID() string { return s.ID }
+
+// RecordID returns the CostOption ID
+func (c CostOption) RecordID() string { return c.ID }
+
+// RecordID returns the Metadata ID
+func (m Metadata) RecordID() string { return m.ID }
+
+// RecordID returns the MetaTableDescription ID
+func (m MetaTableDescription) RecordID() string { return m.ID }
@@ -0,0 +1,152 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ResponseMergeStrategy decides which version of a record appearing in more
+// than one response is kept
+type ResponseMergeStrategy string
+
+// ResponseMergeStrategy values
+const (
+	// ResponseMergeNewest keeps the version with the latest UpdatedAt; ties go to the later response
+	ResponseMergeNewest ResponseMergeStrategy = "newest"
+	// ResponseMergeFirstSource keeps the version from the earliest response
+	ResponseMergeFirstSource ResponseMergeStrategy = "first_source"
+	// ResponseMergeFields keeps the newest version and fills its empty fields from older ones
+	ResponseMergeFields ResponseMergeStrategy = "fields"
+)
+
+// ResponseMergeOptions contains optional settings for MergeJSONResponses
+type ResponseMergeOptions struct {
+	// Strategy resolves records found in more than one response, default newest
+	Strategy ResponseMergeStrategy
+}
+
+// ResponseCollision is an ID whose versions differ between responses
+type ResponseCollision struct {
+	ID string `json:"id"`
+	// Responses are the indexes of the responses containing the ID, in order
+	Responses []int `json:"responses"`
+	// Fields are the JSON names of the fields that differ between any two versions
+	Fields []string `json:"fields"`
+}
+
+// ResponseMergeReport lists the IDs that collided with differing content,
+// in the order they first appeared
+type ResponseMergeReport struct {
+	Collisions []ResponseCollision `json:"collisions"`
+}
+
+// UnmarshalMultipleJSONResponses unmarshals multiple JSON responses into a single
+// slice of type T, deduplicating by ID field and keeping the newest version of
+// each record. T must be a struct with a string ID field. Records are returned
+// in the order their IDs first appear.
+func UnmarshalMultipleJSONResponses[T any](responses [][]byte) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+	if f, ok := t.FieldByName("ID"); !ok || f.Type.Kind() != reflect.String {
+		return nil, fmt.Errorf("%s has no string ID field", t)
+	}
+	items, _, err := mergeResponses(responses, nil, func(item T) string {
+		return reflect.ValueOf(item).FieldByName("ID").String()
+	})
+	return items, err
+}
+
+// MergeJSONResponses unmarshals multiple JSON responses into a single slice of
+// type T, resolving records that share an ID with the chosen strategy. Records
+// are returned in the order their IDs first appear, and the report lists the
+// IDs whose versions differed.
+func MergeJSONResponses[T Record](responses [][]byte, opts *ResponseMergeOptions) ([]T, *ResponseMergeReport, error) {
+	return mergeResponses(responses, opts, T.RecordID)
+}
+
+// mergeResponses implements MergeJSONResponses for any struct type whose
+// records are identified by idOf
+func mergeResponses[T any](responses [][]byte, opts *ResponseMergeOptions, idOf func(T) string) ([]T, *ResponseMergeReport, error) {
+	if opts == nil {
+		opts = &ResponseMergeOptions{}
+	}
+	switch opts.Strategy {
+	case "", ResponseMergeNewest, ResponseMergeFirstSource, ResponseMergeFields:
+	default:
+		return nil, nil, fmt.Errorf("unknown response merge strategy %q", opts.Strategy)
+	}
+
+	var result []T
+	positions := make(map[string]int)
+	// a field differs between two versions only if it differs between two
+	// consecutive ones, so each version is compared with the one before it
+	previous := make(map[string]T)
+	collisions := make(map[string]*ResponseCollision)
+	var order []string
+
+	for r, data := range responses {
+		var items []T
+		if err := UnmarshalJSONWithTime(data, &items); err != nil {
+			return nil, nil, fmt.Errorf("unmarshalling response %d: %w", r, err)
+		}
+
+		for _, item := range items {
+			id := idOf(item)
+			i, seen := positions[id]
+			if !seen {
+				positions[id] = len(result)
+				previous[id] = item
+				collisions[id] = &ResponseCollision{ID: id, Responses: []int{r}}
+				order = append(order, id)
+				result = append(result, item)
+				continue
+			}
+
+			c := collisions[id]
+			c.Responses = append(c.Responses, r)
+			for _, change := range Diff(previous[id], item) {
+				if !contains(c.Fields, change.Field) {
+					c.Fields = append(c.Fields, change.Field)
+				}
+			}
+			previous[id] = item
+			result[i] = mergeResponseRecords(result[i], item, opts.Strategy)
+		}
+	}
+
+	report := &ResponseMergeReport{}
+	for _, id := range order {
+		if c := collisions[id]; len(c.Fields) > 0 {
+			sort.Strings(c.Fields)
+			report.Collisions = append(report.Collisions, *c)
+		}
+	}
+	return result, report, nil
+}
+
+// mergeResponseRecords resolves a record already collected and a later
+// version of it from another response
+func mergeResponseRecords[T any](current, incoming T, strategy ResponseMergeStrategy) T {
+	if strategy == ResponseMergeFirstSource {
+		return current
+	}
+
+	newer, older := incoming, current
+	if lastModified(reflect.ValueOf(current)).After(lastModified(reflect.ValueOf(incoming))) {
+		newer, older = current, incoming
+	}
+	if strategy != ResponseMergeFields {
+		return newer
+	}
+
+	vn, vo := reflect.ValueOf(&newer).Elem(), reflect.ValueOf(older)
+	for _, field := range dataFields(vn.Type()) {
+		if isEmptyValue(vn.Field(field.index)) && !isEmptyValue(vo.Field(field.index)) {
+			vn.Field(field.index).Set(vo.Field(field.index))
+		}
+	}
+	return newer
+}
@@ -0,0 +1,91 @@
+package hsds_types
+
+import (
+	"reflect"
+	"testing"
+)
+
+// responseFeeds returns three pages listing the same service, renamed in the
+// second and renamed back in the third, which also adds an email
+func responseFeeds() [][]byte {
+	return [][]byte{
+		[]byte(`[{"id":"s1","name":"Pantry","description":"Groceries","updated_at":"2024-03-01T00:00:00Z"},{"id":"s2","name":"Clinic"}]`),
+		[]byte(`[{"id":"s1","name":"Food Pantry","description":"","updated_at":"2024-03-03T00:00:00Z"}]`),
+		[]byte(`[{"id":"s1","name":"Pantry","email":"info@example.org","updated_at":"2024-03-02T00:00:00Z"}]`),
+	}
+}
+
+func TestMergeJSONResponses(t *testing.T) {
+	tests := []struct {
+		strategy        ResponseMergeStrategy
+		wantName        string
+		wantDescription string
+		wantEmail       bool
+	}{
+		{"", "Food Pantry", "", false},
+		{ResponseMergeNewest, "Food Pantry", "", false},
+		{ResponseMergeFirstSource, "Pantry", "Groceries", false},
+		{ResponseMergeFields, "Food Pantry", "Groceries", true},
+	}
+	for _, tt := range tests {
+		services, report, err := MergeJSONResponses[Service](responseFeeds(), &ResponseMergeOptions{Strategy: tt.strategy})
+		if err != nil {
+			t.Fatalf("%q: %v", tt.strategy, err)
+		}
+		if len(services) != 2 || services[0].ID != "s1" || services[1].ID != "s2" {
+			t.Fatalf("%q: services = %+v, want s1 then s2", tt.strategy, services)
+		}
+		got := services[0]
+		desc := ""
+		if got.Description != nil {
+			desc = *got.Description
+		}
+		if got.Name != tt.wantName || desc != tt.wantDescription || (got.Email != nil) != tt.wantEmail {
+			t.Errorf("%q: s1 = %q %q %v", tt.strategy, got.Name, desc, got.Email)
+		}
+
+		// the third version matches the first name but differs from the
+		// second, so name is reported along with description and email
+		want := []ResponseCollision{{ID: "s1", Responses: []int{0, 1, 2}, Fields: []string{"description", "email", "name"}}}
+		if !reflect.DeepEqual(report.Collisions, want) {
+			t.Errorf("%q: collisions = %+v, want %+v", tt.strategy, report.Collisions, want)
+		}
+	}
+
+	if _, _, err := MergeJSONResponses[Service](responseFeeds(), &ResponseMergeOptions{Strategy: "longest"}); err == nil {
+		t.Error("MergeJSONResponses accepted an unknown strategy")
+	}
+}
+
+func TestMergeJSONResponsesIdenticalVersions(t *testing.T) {
+	page := []byte(`[{"id":"p1","number":"+12065551212"}]`)
+	_, report, err := MergeJSONResponses[Phone]([][]byte{page, page}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Collisions) != 0 {
+		t.Errorf("collisions = %+v, want none for identical versions", report.Collisions)
+	}
+}
+
+func TestUnmarshalMultipleJSONResponses(t *testing.T) {
+	// any struct with a string ID works, not only HSDS records
+	type row struct {
+		ID    string `json:"id"`
+		Label string `json:"label"`
+	}
+	rows, err := UnmarshalMultipleJSONResponses[row]([][]byte{
+		[]byte(`[{"id":"a","label":"one"},{"id":"b","label":"two"}]`),
+		[]byte(`[{"id":"a","label":"uno"}]`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []row{{"a", "uno"}, {"b", "two"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+
+	if _, err := UnmarshalMultipleJSONResponses[string]([][]byte{[]byte(`["a"]`)}); err == nil {
+		t.Error("UnmarshalMultipleJSONResponses accepted a type without an ID")
+	}
+}
@@ -0,0 +1,117 @@
+package hsds_types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// weekdayNames maps iCal BYDAY codes to display names
+var weekdayNames = map[string]string{
+	"MO": "Mon",
+	"TU": "Tue",
+	"WE": "Wed",
+	"TH": "Thu",
+	"FR": "Fri",
+	"SA": "Sat",
+	"SU": "Sun",
+}
+
+// Humanize renders a schedule as a short, human-readable sentence such as
+// "Weekly on Mon, Wed, Fri, 9:00 AM - 5:00 PM"
+func (s *Schedule) Humanize() string {
+	var parts []string
+
+	if s.Freq != nil {
+		rule := ""
+		switch *s.Freq {
+		case ScheduleFreqWeekly:
+			rule = "Weekly"
+		case ScheduleFreqMonthly:
+			rule = "Monthly"
+		default:
+			rule = string(*s.Freq)
+		}
+		if s.Interval != nil && *s.Interval > 1 {
+			unit := "weeks"
+			if *s.Freq == ScheduleFreqMonthly {
+				unit = "months"
+			}
+			rule = fmt.Sprintf("Every %d %s", *s.Interval, unit)
+		}
+		if days := humanizeByday(s.Byday); days != "" {
+			rule += " on " + days
+		} else if s.Bymonthday != nil && *s.Bymonthday != "" {
+			rule += " on day " + *s.Bymonthday
+		}
+		parts = append(parts, rule)
+	} else if days := humanizeByday(s.Byday); days != "" {
+		parts = append(parts, days)
+	}
+
+	if s.OpensAt != nil && s.ClosesAt != nil {
+		parts = append(parts, s.OpensAt.Format("3:04 PM")+" - "+s.ClosesAt.Format("3:04 PM"))
+	} else if s.OpensAt != nil {
+		parts = append(parts, "opens "+s.OpensAt.Format("3:04 PM"))
+	} else if s.ClosesAt != nil {
+		parts = append(parts, "closes "+s.ClosesAt.Format("3:04 PM"))
+	}
+
+	if s.ValidFrom != nil && s.ValidTo != nil {
+		parts = append(parts, "from "+s.ValidFrom.Format("Jan 2, 2006")+" to "+s.ValidTo.Format("Jan 2, 2006"))
+	} else if s.ValidFrom != nil {
+		parts = append(parts, "from "+s.ValidFrom.Format("Jan 2, 2006"))
+	} else if s.ValidTo != nil {
+		parts = append(parts, "until "+s.ValidTo.Format("Jan 2, 2006"))
+	}
+
+	if len(parts) == 0 {
+		if s.Description != nil {
+			return *s.Description
+		}
+		return ""
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// humanizeByday converts a BYDAY list such as "MO,WE,1FR" into display names
+func humanizeByday(byday *string) string {
+	if byday == nil || *byday == "" {
+		return ""
+	}
+
+	var days []string
+	for _, code := range strings.Split(*byday, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if len(code) < 2 {
+			continue
+		}
+		prefix, day := code[:len(code)-2], code[len(code)-2:]
+		name, ok := weekdayNames[day]
+		if !ok {
+			continue
+		}
+		if prefix != "" {
+			name = ordinal(prefix) + " " + name
+		}
+		days = append(days, name)
+	}
+
+	return strings.Join(days, ", ")
+}
+
+// ordinal renders a BYDAY position prefix such as "1", "-1" or "+2"
+func ordinal(prefix string) string {
+	switch strings.TrimPrefix(prefix, "+") {
+	case "1":
+		return "1st"
+	case "2":
+		return "2nd"
+	case "3":
+		return "3rd"
+	case "-1":
+		return "last"
+	default:
+		return strings.TrimPrefix(prefix, "+") + "th"
+	}
+}
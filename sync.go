@@ -0,0 +1,391 @@
+package hsds_types
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SyncChange is a change the sync engine applied to the local dataset
+type SyncChange struct {
+	Op      PatchOp                  `json:"op"`
+	Table   MetadataResourceTypeEnum `json:"table"`
+	ID      string                   `json:"id"`
+	Changes []FieldChange            `json:"changes,omitempty"`
+}
+
+// SyncConflict is a field, or a whole record when Field is empty, that changed
+// both upstream and locally since the last sync. Local and Upstream hold the
+// competing values; a whole-record conflict holds the records, nil where deleted.
+type SyncConflict struct {
+	Table      MetadataResourceTypeEnum `json:"table"`
+	ID         string                   `json:"id"`
+	Field      string                   `json:"field,omitempty"`
+	Base       any                      `json:"base"`
+	Local      any                      `json:"local"`
+	Upstream   any                      `json:"upstream"`
+	DetectedAt time.Time                `json:"detected_at"`
+}
+
+// SyncReport summarises one Sync run
+type SyncReport struct {
+	Applied   []SyncChange   `json:"applied"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+// SyncOptions contains optional settings for Syncer.Sync
+type SyncOptions struct {
+	// ChangeSet receives Metadata rows for every change applied locally
+	ChangeSet *ChangeSet
+}
+
+// Syncer merges an upstream feed into a locally edited dataset. Base is the
+// upstream version of each record as of the last sync and Conflicts is the
+// queue awaiting review; persist both between runs.
+type Syncer struct {
+	Base      *Dataset       `json:"base"`
+	Conflicts []SyncConflict `json:"conflicts"`
+	// Factory stamps detected conflicts and applied updates, default the package Factory
+	Factory *Factory `json:"-"`
+}
+
+// NewSyncer creates a Syncer whose last-synced upstream state is base, which
+// may be nil before the first sync
+func NewSyncer(base *Dataset) *Syncer {
+	return defaultFactory.NewSyncer(base)
+}
+
+// NewSyncer creates a Syncer that stamps its changes with the factory's clock
+func (f *Factory) NewSyncer(base *Dataset) *Syncer {
+	if base == nil {
+		base = &Dataset{}
+	}
+	return &Syncer{Base: base, Factory: f}
+}
+
+// Sync three-way merges upstream into local against Base, field by field.
+// Fields changed only upstream are applied, fields changed only locally are
+// kept, and fields changed on both sides to different values are queued as
+// conflicts with the local value left in place. Base then becomes upstream,
+// and queued conflicts on which local and upstream now agree are dropped. On
+// error local, the change set and the Syncer are left unchanged.
+func (s *Syncer) Sync(local, upstream *Dataset, opts *SyncOptions) (*SyncReport, error) {
+	if local == nil || upstream == nil {
+		return nil, fmt.Errorf("local and upstream datasets are required")
+	}
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	saved := local.copyTables()
+	var savedRows []*Metadata
+	if opts.ChangeSet != nil {
+		savedRows = opts.ChangeSet.Rows
+	}
+	report, err := s.sync(local, upstream, opts)
+	if err != nil {
+		*local = saved
+		if opts.ChangeSet != nil {
+			opts.ChangeSet.Rows = savedRows
+		}
+		return nil, err
+	}
+
+	s.queue(report.Conflicts)
+	s.dropSettled(local, upstream)
+	s.Base = upstream.Clone()
+	return report, nil
+}
+
+// sync implements Sync, changing local in place
+func (s *Syncer) sync(local, upstream *Dataset, opts *SyncOptions) (*SyncReport, error) {
+	report := &SyncReport{}
+	now := factoryOr(s.Factory).now()
+	vb, vl, vu := reflect.ValueOf(s.Base).Elem(), reflect.ValueOf(local).Elem(), reflect.ValueOf(upstream).Elem()
+
+	for _, table := range tableOrder {
+		if table == ResourceTypeMetadata {
+			continue
+		}
+		index := datasetTables[table]
+		base, upstreamRows := recordsByID(vb.Field(index)), recordsByID(vu.Field(index))
+		localRows := vl.Field(index)
+
+		for _, id := range unionIDs(vb.Field(index), vu.Field(index)) {
+			b, hasBase := base[id]
+			u, hasUpstream := upstreamRows[id]
+			li := indexOfID(localRows, id)
+
+			switch {
+			case li < 0 && !hasUpstream:
+				// deleted on both sides
+			case li < 0 && !hasBase:
+				if err := local.Insert(table, deepCopy(u).Interface()); err != nil {
+					return nil, err
+				}
+				if err := s.track(opts, nil, u.Interface()); err != nil {
+					return nil, err
+				}
+				report.Applied = append(report.Applied, SyncChange{Op: PatchAdd, Table: table, ID: id})
+			case li < 0:
+				if len(Diff(b.Interface(), u.Interface())) > 0 {
+					report.Conflicts = append(report.Conflicts, SyncConflict{Table: table, ID: id, Base: b.Interface(), Upstream: u.Interface(), DetectedAt: now})
+				}
+			case !hasUpstream:
+				l := localRows.Index(li)
+				if !Equal(b.Interface(), l.Interface(), nil) {
+					report.Conflicts = append(report.Conflicts, SyncConflict{Table: table, ID: id, Base: b.Interface(), Local: l.Interface(), DetectedAt: now})
+					continue
+				}
+				if err := s.track(opts, l.Interface(), nil); err != nil {
+					return nil, err
+				}
+				if err := local.Delete(table, id); err != nil {
+					return nil, err
+				}
+				report.Applied = append(report.Applied, SyncChange{Op: PatchRemove, Table: table, ID: id})
+			default:
+				if !hasBase {
+					b = reflect.Zero(u.Type())
+				}
+				l := localRows.Index(li)
+				before := deepCopy(l).Interface()
+				changes, conflicts := mergeThreeWay(b, l, u)
+				for i := range conflicts {
+					conflicts[i].Table, conflicts[i].ID, conflicts[i].DetectedAt = table, id, now
+				}
+				report.Conflicts = append(report.Conflicts, conflicts...)
+				if len(changes) == 0 {
+					continue
+				}
+				l.FieldByName("UpdatedAt").Set(reflect.ValueOf(FlexTime{now}))
+				if err := s.track(opts, before, l.Interface()); err != nil {
+					return nil, err
+				}
+				report.Applied = append(report.Applied, SyncChange{Op: PatchUpdate, Table: table, ID: id, Changes: changes})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// Resolve settles a queued conflict, applying the upstream value to local when
+// takeUpstream is set and keeping the local value otherwise. The decision is
+// written to local's Metadata: the changed fields when upstream is taken, a
+// verify row when local is kept.
+func (s *Syncer) Resolve(local *Dataset, conflict SyncConflict, takeUpstream bool) error {
+	i := s.conflictIndex(conflict)
+	if i < 0 {
+		return fmt.Errorf("no conflict queued for %s %s %s", conflict.Table, conflict.ID, conflict.Field)
+	}
+	queued := s.Conflicts[i]
+	f := factoryOr(s.Factory)
+
+	var (
+		history []*Metadata
+		apply   func() error
+		err     error
+	)
+	switch {
+	case !takeUpstream:
+		history, err = s.verifyRow(queued)
+		apply = func() error { return nil }
+	case queued.Field != "":
+		changes := []FieldChange{{Field: queued.Field, Previous: queued.Local, Replacement: queued.Upstream}}
+		history, err = s.trackResolution(local, queued, func(record any) (any, error) {
+			after := deepCopy(reflect.ValueOf(record)).Interface()
+			return after, ApplyFieldChanges(after, changes)
+		})
+		apply = func() error { return local.update(f, queued.Table, queued.ID, changes) }
+	case queued.Upstream == nil:
+		history, err = s.trackResolution(local, queued, func(any) (any, error) { return nil, nil })
+		apply = func() error { return local.Delete(queued.Table, queued.ID) }
+	default:
+		var record any
+		if record, err = decodeRecord(queued.Table, queued.Upstream); err == nil {
+			history, err = f.TrackChanges(nil, record, "", "")
+		}
+		apply = func() error { return local.Insert(queued.Table, record) }
+	}
+	if err != nil {
+		return err
+	}
+	if err := apply(); err != nil {
+		return err
+	}
+
+	for _, meta := range history {
+		local.Metadata = append(local.Metadata, *meta)
+	}
+	s.Conflicts = append(s.Conflicts[:i], s.Conflicts[i+1:]...)
+	return nil
+}
+
+// trackResolution returns the Metadata rows for changing the local record of
+// a conflict to the version change returns, nil to delete it
+func (s *Syncer) trackResolution(local *Dataset, c SyncConflict, change func(record any) (any, error)) ([]*Metadata, error) {
+	rows, err := local.table(c.Table)
+	if err != nil {
+		return nil, err
+	}
+	li := indexOfID(rows, c.ID)
+	if li < 0 {
+		return nil, fmt.Errorf("%s %s not found", c.Table, c.ID)
+	}
+	before := rows.Index(li).Addr().Interface()
+	after, err := change(before)
+	if err != nil {
+		return nil, err
+	}
+	return factoryOr(s.Factory).TrackChanges(before, after, "", "")
+}
+
+// verifyRow returns the Metadata row recording that the local side of a
+// conflict was reviewed and kept
+func (s *Syncer) verifyRow(c SyncConflict) ([]*Metadata, error) {
+	field, value := c.Field, formatFieldValue(reflect.ValueOf(c.Local))
+	if field == "" {
+		field, value = "id", c.ID
+	}
+	meta, err := factoryOr(s.Factory).NewMetadata(MetadataOptions{
+		ResourceID:       c.ID,
+		ResourceType:     c.Table,
+		LastActionType:   ActionTypeVerify,
+		FieldName:        field,
+		PreviousValue:    value,
+		ReplacementValue: value,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []*Metadata{meta}, nil
+}
+
+// mergeThreeWay applies upstream field changes to the local record l and
+// returns them along with the fields that changed on both sides
+func mergeThreeWay(b, l, u reflect.Value) ([]FieldChange, []SyncConflict) {
+	var changes []FieldChange
+	var conflicts []SyncConflict
+	for _, field := range dataFields(l.Type()) {
+		fb, fl, fu := b.Field(field.index), l.Field(field.index), u.Field(field.index)
+		switch {
+		case fieldValuesEqual(fu, fb), fieldValuesEqual(fl, fu):
+			continue
+		case fieldValuesEqual(fl, fb):
+			changes = append(changes, FieldChange{Field: field.name, Previous: derefValue(fl), Replacement: derefValue(fu)})
+			fl.Set(deepCopy(fu))
+		default:
+			conflicts = append(conflicts, SyncConflict{
+				Field:    field.name,
+				Base:     derefValue(fb),
+				Local:    derefValue(fl),
+				Upstream: derefValue(fu),
+			})
+		}
+	}
+	return changes, conflicts
+}
+
+// queue adds conflicts, replacing any already queued for the same field
+func (s *Syncer) queue(conflicts []SyncConflict) {
+	for _, c := range conflicts {
+		if i := s.conflictIndex(c); i >= 0 {
+			s.Conflicts[i] = c
+			continue
+		}
+		s.Conflicts = append(s.Conflicts, c)
+	}
+}
+
+// dropSettled removes queued conflicts on which local and upstream now agree:
+// the field holds the same value on both sides, or the record is equal or
+// deleted on both
+func (s *Syncer) dropSettled(local, upstream *Dataset) {
+	kept := s.Conflicts[:0]
+	for _, c := range s.Conflicts {
+		if !conflictSettled(local, upstream, c) {
+			kept = append(kept, c)
+		}
+	}
+	s.Conflicts = kept
+}
+
+// conflictSettled reports whether local and upstream agree on a conflict
+func conflictSettled(local, upstream *Dataset, c SyncConflict) bool {
+	lrows, err := local.table(c.Table)
+	if err != nil {
+		return false
+	}
+	urows, _ := upstream.table(c.Table)
+	li, ui := indexOfID(lrows, c.ID), indexOfID(urows, c.ID)
+	switch {
+	case li < 0 || ui < 0:
+		return c.Field == "" && li < 0 && ui < 0
+	case c.Field == "":
+		return Equal(lrows.Index(li).Interface(), urows.Index(ui).Interface(), nil)
+	}
+	l, u := lrows.Index(li), urows.Index(ui)
+	for _, field := range dataFields(l.Type()) {
+		if field.name == c.Field {
+			return fieldValuesEqual(l.Field(field.index), u.Field(field.index))
+		}
+	}
+	return false
+}
+
+// conflictIndex returns the position of the queued conflict for the same field, or -1
+func (s *Syncer) conflictIndex(c SyncConflict) int {
+	for i, queued := range s.Conflicts {
+		if queued.Table == c.Table && queued.ID == c.ID && queued.Field == c.Field {
+			return i
+		}
+	}
+	return -1
+}
+
+// track records a change in the change set, if there is one
+func (s *Syncer) track(opts *SyncOptions, old, new any) error {
+	if opts.ChangeSet == nil {
+		return nil
+	}
+	return opts.ChangeSet.Track(old, new)
+}
+
+// decodeRecord converts a record, or its decoded JSON form when the conflict
+// queue was persisted, to a pointer to the table's record type
+func decodeRecord(table MetadataResourceTypeEnum, record any) (any, error) {
+	index, ok := datasetTables[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown table %q", table)
+	}
+	value, err := decodeFieldValue(reflect.PointerTo(reflect.TypeOf(Dataset{}).Field(index).Type.Elem()), record)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// recordsByID indexes the records of a table slice by ID
+func recordsByID(rows reflect.Value) map[string]reflect.Value {
+	result := make(map[string]reflect.Value, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		result[rows.Index(i).FieldByName("ID").String()] = rows.Index(i)
+	}
+	return result
+}
+
+// unionIDs returns the IDs of both table slices, in order of first appearance
+func unionIDs(a, b reflect.Value) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, rows := range []reflect.Value{a, b} {
+		for i := 0; i < rows.Len(); i++ {
+			if id := rows.Index(i).FieldByName("ID").String(); !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,193 @@
+package hsds_types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// syncFixture returns a synced base plus local and upstream copies of it
+func syncFixture() (base, local, upstream *Dataset) {
+	org := testID(1)
+	base = &Dataset{
+		Organizations: []Organization{{ID: org, Name: "Food Bank", Description: "Groceries", Email: strPtr("info@example.org")}},
+		Services: []Service{
+			{ID: testID(2), OrganizationID: org, Name: "Pantry", Status: ServiceStatusActive},
+			{ID: testID(3), OrganizationID: org, Name: "Meals", Status: ServiceStatusActive},
+		},
+	}
+	return base, base.Clone(), base.Clone()
+}
+
+func TestSyncerSync(t *testing.T) {
+	base, local, upstream := syncFixture()
+
+	// local staff fix the description and rename; upstream renames differently,
+	// changes the email, drops Meals and adds a Clinic
+	local.Organizations[0].Description = "Groceries and hot meals"
+	local.Organizations[0].Name = "Food Bank NW"
+	upstream.Organizations[0].Name = "Northwest Food Bank"
+	upstream.Organizations[0].Email = strPtr("hello@example.org")
+	upstream.Services = []Service{upstream.Services[0], {ID: testID(4), OrganizationID: testID(1), Name: "Clinic", Status: ServiceStatusActive}}
+
+	s := newTestFactory().NewSyncer(base)
+	cs := newTestFactory().NewChangeSet("sync")
+	report, err := s.Sync(local, upstream, &SyncOptions{ChangeSet: cs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := local.Organizations[0]
+	if org.Description != "Groceries and hot meals" || *org.Email != "hello@example.org" || org.Name != "Food Bank NW" {
+		t.Errorf("organization = %q %q %q", org.Name, org.Description, *org.Email)
+	}
+	if !org.UpdatedAt.Equal(testTime) {
+		t.Errorf("UpdatedAt = %v, want the factory clock", org.UpdatedAt)
+	}
+	if len(local.Services) != 2 || local.ServiceByID(testID(3)) != nil || local.ServiceByID(testID(4)) == nil {
+		t.Errorf("services = %+v, want Meals removed and Clinic added", local.Services)
+	}
+
+	applied := make(map[PatchOp]int)
+	for _, c := range report.Applied {
+		applied[c.Op]++
+	}
+	if applied[PatchAdd] != 1 || applied[PatchRemove] != 1 || applied[PatchUpdate] != 1 {
+		t.Errorf("applied = %v, want one of each", applied)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Field != "name" || report.Conflicts[0].Upstream != "Northwest Food Bank" {
+		t.Fatalf("conflicts = %+v, want the name", report.Conflicts)
+	}
+	if !report.Conflicts[0].DetectedAt.Equal(testTime) || len(s.Conflicts) != 1 {
+		t.Errorf("queued conflicts = %+v", s.Conflicts)
+	}
+	if len(cs.Rows) == 0 {
+		t.Error("change set recorded no rows")
+	}
+	if s.Base.Organizations[0].Name != "Northwest Food Bank" {
+		t.Error("Base was not advanced to upstream")
+	}
+
+	// a second sync of the same upstream changes nothing and keeps the queue
+	report, err = s.Sync(local, upstream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Applied) != 0 || len(report.Conflicts) != 0 || len(s.Conflicts) != 1 {
+		t.Errorf("resync applied %d, conflicts %d, queued %d", len(report.Applied), len(report.Conflicts), len(s.Conflicts))
+	}
+}
+
+func TestSyncerResolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		takeUpstream bool
+		want         string
+		action       MetadataLastActionTypeEnum
+	}{
+		{"keep local", false, "Food Bank NW", ActionTypeVerify},
+		{"take upstream", true, "Northwest Food Bank", ActionTypeUpdate},
+	}
+	for _, tt := range tests {
+		base, local, upstream := syncFixture()
+		local.Organizations[0].Name = "Food Bank NW"
+		upstream.Organizations[0].Name = "Northwest Food Bank"
+		s := newTestFactory().NewSyncer(base)
+		if _, err := s.Sync(local, upstream, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		// the queue survives a round trip through JSON between runs
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := &Syncer{Factory: newTestFactory()}
+		if err := json.Unmarshal(data, restored); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := restored.Resolve(local, restored.Conflicts[0], tt.takeUpstream); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := local.Organizations[0].Name; got != tt.want {
+			t.Errorf("%s: name = %q, want %q", tt.name, got, tt.want)
+		}
+		if len(restored.Conflicts) != 0 {
+			t.Errorf("%s: conflict still queued", tt.name)
+		}
+		if len(local.Metadata) != 1 || local.Metadata[0].LastActionType != tt.action || local.Metadata[0].FieldName != "name" || local.Metadata[0].ReplacementValue != tt.want {
+			t.Errorf("%s: metadata = %+v, want one %s row for the name", tt.name, local.Metadata, tt.action)
+		}
+		if err := restored.Resolve(local, SyncConflict{Table: ResourceTypeOrganization, ID: testID(1), Field: "name"}, true); err == nil {
+			t.Errorf("%s: resolved a conflict that is no longer queued", tt.name)
+		}
+	}
+}
+
+func TestSyncerRecordConflicts(t *testing.T) {
+	base, local, upstream := syncFixture()
+	// deleted locally but edited upstream, and edited locally but deleted upstream
+	local.Services = local.Services[1:]
+	upstream.Services[0].Name = "Food Pantry"
+	local.Services[0].Name = "Hot Meals"
+	upstream.Services = upstream.Services[:1]
+
+	s := NewSyncer(base)
+	report, err := s.Sync(local, upstream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want two whole-record conflicts", report.Conflicts)
+	}
+	for _, c := range report.Conflicts {
+		if c.Field != "" {
+			t.Errorf("conflict on %s is for field %q, want the whole record", c.ID, c.Field)
+		}
+	}
+	if len(local.Services) != 1 || local.Services[0].Name != "Hot Meals" {
+		t.Errorf("local services = %+v, want the local edit kept", local.Services)
+	}
+}
+
+func TestSyncerDropsSettledConflicts(t *testing.T) {
+	base, local, upstream := syncFixture()
+	local.Organizations[0].Name = "Food Bank NW"
+	upstream.Organizations[0].Name = "Northwest Food Bank"
+	s := newTestFactory().NewSyncer(base)
+	if _, err := s.Sync(local, upstream, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Conflicts) != 1 {
+		t.Fatalf("queued = %+v, want the name", s.Conflicts)
+	}
+
+	// staff adopt the upstream name outside Resolve
+	local.Organizations[0].Name = "Northwest Food Bank"
+	if _, err := s.Sync(local, upstream, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Conflicts) != 0 {
+		t.Errorf("queued = %+v, want the settled conflict dropped", s.Conflicts)
+	}
+}
+
+func TestSyncerSyncFailureLeavesLocalUnchanged(t *testing.T) {
+	base, local, upstream := syncFixture()
+	upstream.Organizations[0].Description = "Groceries and meals"
+	// a record without a UUID cannot get a Metadata row
+	upstream.Services = append(upstream.Services, Service{ID: "legacy-7", OrganizationID: testID(1), Name: "Clinic"})
+
+	s := newTestFactory().NewSyncer(base)
+	cs := newTestFactory().NewChangeSet("sync")
+	before := local.Clone()
+	if _, err := s.Sync(local, upstream, &SyncOptions{ChangeSet: cs}); err == nil {
+		t.Fatal("Sync succeeded")
+	}
+	if d := DiffDatasets(before, local); len(d.Tables) != 0 || !local.Organizations[0].UpdatedAt.IsZero() {
+		t.Errorf("local changed by a failed sync: %+v", d.Tables)
+	}
+	if len(cs.Rows) != 0 || s.Base != base {
+		t.Errorf("change set rows = %d, base replaced = %v", len(cs.Rows), s.Base != base)
+	}
+}
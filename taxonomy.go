@@ -0,0 +1,219 @@
+package hsds_types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TaxonomyTree indexes a set of TaxonomyTerms by their ParentID hierarchy
+type TaxonomyTree struct {
+	terms    map[string]*TaxonomyTerm
+	children map[string][]string
+	roots    []string
+}
+
+// NewTaxonomyTree builds a tree from terms. Terms whose parent is missing are
+// treated as roots. Duplicate IDs and parent cycles are rejected.
+func NewTaxonomyTree(terms []TaxonomyTerm) (*TaxonomyTree, error) {
+	tree := &TaxonomyTree{
+		terms:    make(map[string]*TaxonomyTerm, len(terms)),
+		children: make(map[string][]string),
+	}
+
+	for i := range terms {
+		term := terms[i]
+		if _, exists := tree.terms[term.ID]; exists {
+			return nil, fmt.Errorf("duplicate taxonomy term ID %s", term.ID)
+		}
+		tree.terms[term.ID] = &term
+	}
+
+	for id, term := range tree.terms {
+		if term.ParentID != nil && *term.ParentID != "" {
+			if _, ok := tree.terms[*term.ParentID]; ok {
+				tree.children[*term.ParentID] = append(tree.children[*term.ParentID], id)
+				continue
+			}
+		}
+		tree.roots = append(tree.roots, id)
+	}
+
+	if cycle := tree.findCycle(); cycle != nil {
+		return nil, fmt.Errorf("taxonomy terms form a cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	tree.sortIDs(tree.roots)
+	for _, ids := range tree.children {
+		tree.sortIDs(ids)
+	}
+
+	return tree, nil
+}
+
+// Term returns the term with the given ID, or nil if absent
+func (t *TaxonomyTree) Term(id string) *TaxonomyTerm {
+	return t.terms[id]
+}
+
+// Roots returns the top-level terms ordered by code, then name
+func (t *TaxonomyTree) Roots() []TaxonomyTerm {
+	return t.collect(t.roots)
+}
+
+// Children returns the direct children of a term
+func (t *TaxonomyTree) Children(id string) []TaxonomyTerm {
+	return t.collect(t.children[id])
+}
+
+// Ancestors returns the parents of a term, nearest first
+func (t *TaxonomyTree) Ancestors(id string) []TaxonomyTerm {
+	var result []TaxonomyTerm
+	for parent := t.parentOf(id); parent != nil; parent = t.parentOf(parent.ID) {
+		result = append(result, *parent)
+	}
+	return result
+}
+
+// Descendants returns every term below id in depth-first order
+func (t *TaxonomyTree) Descendants(id string) []TaxonomyTerm {
+	var result []TaxonomyTerm
+	var walk func(string)
+	walk = func(parent string) {
+		for _, child := range t.children[parent] {
+			result = append(result, *t.terms[child])
+			walk(child)
+		}
+	}
+	walk(id)
+	return result
+}
+
+// Path returns the terms from the root down to and including id
+func (t *TaxonomyTree) Path(id string) []TaxonomyTerm {
+	term := t.terms[id]
+	if term == nil {
+		return nil
+	}
+	ancestors := t.Ancestors(id)
+	path := make([]TaxonomyTerm, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		path = append(path, ancestors[i])
+	}
+	return append(path, *term)
+}
+
+// Depth returns the number of ancestors of a term; roots have depth 0.
+// Unknown IDs return -1.
+func (t *TaxonomyTree) Depth(id string) int {
+	if t.terms[id] == nil {
+		return -1
+	}
+	return len(t.Ancestors(id))
+}
+
+// FindByName returns the terms whose name matches case-insensitively
+func (t *TaxonomyTree) FindByName(name string) []TaxonomyTerm {
+	var ids []string
+	for id, term := range t.terms {
+		if strings.EqualFold(term.Name, strings.TrimSpace(name)) {
+			ids = append(ids, id)
+		}
+	}
+	t.sortIDs(ids)
+	return t.collect(ids)
+}
+
+// SubtreeIDs returns id together with the IDs of all its descendants
+func (t *TaxonomyTree) SubtreeIDs(id string) map[string]bool {
+	ids := map[string]bool{id: true}
+	for _, term := range t.Descendants(id) {
+		ids[term.ID] = true
+	}
+	return ids
+}
+
+// ServicesForTerm returns the services tagged, via Attribute, with the term
+// or any of its descendants, so "Food" matches services tagged "Food Pantries"
+func (t *TaxonomyTree) ServicesForTerm(ds *Dataset, id string) []Service {
+	subtree := t.SubtreeIDs(id)
+
+	tagged := make(map[string]bool)
+	for _, attr := range ds.Attributes {
+		if subtree[attr.TaxonomyTermID] && strings.EqualFold(attr.LinkEntity, string(LinkEntityService)) {
+			tagged[attr.LinkID] = true
+		}
+	}
+
+	var result []Service
+	for _, svc := range ds.Services {
+		if tagged[svc.ID] {
+			result = append(result, svc)
+		}
+	}
+	return result
+}
+
+// parentOf returns the parent term, or nil for roots
+func (t *TaxonomyTree) parentOf(id string) *TaxonomyTerm {
+	term := t.terms[id]
+	if term == nil || term.ParentID == nil {
+		return nil
+	}
+	return t.terms[*term.ParentID]
+}
+
+// findCycle returns the IDs of a parent cycle, or nil if the hierarchy is acyclic
+func (t *TaxonomyTree) findCycle() []string {
+	done := make(map[string]bool, len(t.terms))
+	for start := range t.terms {
+		seen := make(map[string]int)
+		var path []string
+		for id := start; id != "" && !done[id]; {
+			if at, ok := seen[id]; ok {
+				return append(path[at:], id)
+			}
+			seen[id] = len(path)
+			path = append(path, id)
+			parent := t.parentOf(id)
+			if parent == nil {
+				break
+			}
+			id = parent.ID
+		}
+		for _, id := range path {
+			done[id] = true
+		}
+	}
+	return nil
+}
+
+// collect resolves IDs to terms
+func (t *TaxonomyTree) collect(ids []string) []TaxonomyTerm {
+	result := make([]TaxonomyTerm, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *t.terms[id])
+	}
+	return result
+}
+
+// sortIDs orders term IDs by code, then name, then ID
+func (t *TaxonomyTree) sortIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := t.terms[ids[i]], t.terms[ids[j]]
+		ca, cb := "", ""
+		if a.Code != nil {
+			ca = *a.Code
+		}
+		if b.Code != nil {
+			cb = *b.Code
+		}
+		if ca != cb {
+			return ca < cb
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,328 @@
+package hsds_types
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// taxonomyNamespace is the DeriveID namespace of imported taxonomies, so that
+// re-importing the same export yields the same IDs
+const taxonomyNamespace = "5b0e2c8e-6f3a-4d55-9a47-3c1f0f8d7b21"
+
+// airsColumnAliases maps the header names seen in AIRS/211 LA exports to fields
+var airsColumnAliases = map[string]string{
+	"code":          "code",
+	"taxonomy code": "code",
+	"term code":     "code",
+	"name":          "name",
+	"term":          "name",
+	"term name":     "name",
+	"definition":    "description",
+	"description":   "description",
+}
+
+// TaxonomyImportOptions contains optional settings for the taxonomy importers
+type TaxonomyImportOptions struct {
+	// Name and Description override the default taxonomy name and description
+	Name        string
+	Description string
+	URI         *string
+	Version     *string
+	// Language is recorded on every term, default "en"
+	Language string
+	// TermURIBase is prefixed to each term code to build TermURI
+	TermURIBase string
+	// Factory supplies the import time, default the package Factory
+	Factory *Factory
+}
+
+// TaxonomyImport is a taxonomy and its terms read from an external export
+type TaxonomyImport struct {
+	Taxonomy Taxonomy
+	Terms    []TaxonomyTerm
+}
+
+// ImportAIRSTaxonomy reads an AIRS/211 LA taxonomy CSV export with code, name
+// and definition columns. Parents are derived from the code structure, so
+// "BD-1800.2000" is placed under "BD-1800", which is under "BD", under "B".
+func ImportAIRSTaxonomy(r io.Reader, opts *TaxonomyImportOptions) (*TaxonomyImport, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if field, ok := airsColumnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	for _, field := range []string{"code", "name"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("missing required column %q", field)
+		}
+	}
+
+	imp := newTaxonomyImport("AIRS/211 LA County Taxonomy", "AIRS/211 LA County Taxonomy of Human Services", opts)
+
+	type row struct{ code, name, description string }
+	var rows []row
+	codes := make(map[string]bool)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading line %d: %w", line, err)
+		}
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		code := strings.ToUpper(get("code"))
+		if code == "" {
+			continue
+		}
+		if codes[code] {
+			return nil, fmt.Errorf("line %d: duplicate code %s", line, code)
+		}
+		codes[code] = true
+		rows = append(rows, row{code: code, name: get("name"), description: get("description")})
+	}
+
+	for _, r := range rows {
+		parent := airsParentCode(r.code)
+		for parent != "" && !codes[parent] {
+			parent = airsParentCode(parent)
+		}
+		imp.addTerm(r.code, r.name, r.description, parent, opts)
+	}
+
+	return imp, nil
+}
+
+// airsParentCode strips the last level from an AIRS code:
+// "BD-1800.2000-250" -> "BD-1800.2000" -> "BD-1800" -> "BD" -> "B" -> ""
+func airsParentCode(code string) string {
+	if i := strings.LastIndexAny(code, ".-"); i > 0 {
+		return code[:i]
+	}
+	if len(code) > 1 {
+		return code[:len(code)-1]
+	}
+	return ""
+}
+
+// openEligibilityNode is any element of the Open Eligibility taxonomy XML;
+// terms are elements carrying a name attribute and optional id
+type openEligibilityNode struct {
+	XMLName  xml.Name
+	ID       string                `xml:"id,attr"`
+	Name     string                `xml:"name,attr"`
+	Children []openEligibilityNode `xml:",any"`
+}
+
+// ImportOpenEligibilityTaxonomy reads the Open Eligibility taxonomy XML, in
+// which terms are nested elements such as <service id="101" name="Emergency">
+func ImportOpenEligibilityTaxonomy(r io.Reader, opts *TaxonomyImportOptions) (*TaxonomyImport, error) {
+	var root openEligibilityNode
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("decoding Open Eligibility XML: %w", err)
+	}
+
+	imp := newTaxonomyImport("Open Eligibility", "Open Eligibility taxonomy of human services and situations", opts)
+	seen := make(map[string]bool)
+
+	var walk func(nodes []openEligibilityNode, parent string) error
+	walk = func(nodes []openEligibilityNode, parent string) error {
+		for _, node := range nodes {
+			nextParent := parent
+			if name := strings.TrimSpace(node.Name); name != "" {
+				code := strings.TrimSpace(node.ID)
+				if code == "" {
+					code = strings.ToLower(strings.Join(strings.Fields(name), "-"))
+				}
+				if seen[code] {
+					return fmt.Errorf("duplicate Open Eligibility term %s", code)
+				}
+				seen[code] = true
+				imp.addTerm(code, name, "", parent, opts)
+				nextParent = code
+			}
+			if err := walk(node.Children, nextParent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root.Children, ""); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// TaxonomyUpsertResult lists the terms of a taxonomy that a newer import no
+// longer contains
+type TaxonomyUpsertResult struct {
+	// Removed terms were deleted from the dataset
+	Removed []TaxonomyTerm
+	// Retained terms are still referenced by an attribute, or are ancestors of
+	// such a term, and were kept; re-tag the attributes and upsert again to
+	// remove them
+	Retained []TaxonomyTerm
+}
+
+// UpsertTaxonomy adds an imported taxonomy and its terms to the dataset,
+// replacing records with the same ID while keeping their CreatedAt. Replaced
+// records are marked updated at the time of the import. Terms of the taxonomy
+// missing from the import are removed unless attributes still use them.
+func (ds *Dataset) UpsertTaxonomy(imp *TaxonomyImport) *TaxonomyUpsertResult {
+	now := imp.Taxonomy.CreatedAt.Time
+
+	if i := indexByID(ds.Taxonomies, imp.Taxonomy.ID); i >= 0 {
+		taxonomy := imp.Taxonomy
+		taxonomy.CreatedAt = ds.Taxonomies[i].CreatedAt
+		taxonomy.UpdatedAt = FlexTime{now}
+		ds.Taxonomies[i] = taxonomy
+	} else {
+		ds.Taxonomies = append(ds.Taxonomies, imp.Taxonomy)
+	}
+
+	existing := make(map[string]int, len(ds.TaxonomyTerms))
+	for i, term := range ds.TaxonomyTerms {
+		existing[term.ID] = i
+	}
+	for _, term := range imp.Terms {
+		if i, ok := existing[term.ID]; ok {
+			term.CreatedAt = ds.TaxonomyTerms[i].CreatedAt
+			term.UpdatedAt = FlexTime{now}
+			ds.TaxonomyTerms[i] = term
+			continue
+		}
+		ds.TaxonomyTerms = append(ds.TaxonomyTerms, term)
+	}
+
+	return ds.retireTaxonomyTerms(imp)
+}
+
+// retireTaxonomyTerms removes the terms of the imported taxonomy that the
+// import does not contain, keeping those attributes still reference
+func (ds *Dataset) retireTaxonomyTerms(imp *TaxonomyImport) *TaxonomyUpsertResult {
+	imported := make(map[string]bool, len(imp.Terms))
+	for _, term := range imp.Terms {
+		imported[term.ID] = true
+	}
+	dropped := make(map[string]*TaxonomyTerm)
+	for i := range ds.TaxonomyTerms {
+		term := &ds.TaxonomyTerms[i]
+		if term.TaxonomyID != nil && *term.TaxonomyID == imp.Taxonomy.ID && !imported[term.ID] {
+			dropped[term.ID] = term
+		}
+	}
+
+	keep := make(map[string]bool)
+	for _, attr := range ds.Attributes {
+		for id := attr.TaxonomyTermID; dropped[id] != nil && !keep[id]; {
+			keep[id] = true
+			if parent := dropped[id].ParentID; parent != nil {
+				id = *parent
+			}
+		}
+	}
+
+	result := &TaxonomyUpsertResult{}
+	terms := ds.TaxonomyTerms[:0]
+	for _, term := range ds.TaxonomyTerms {
+		switch {
+		case dropped[term.ID] == nil:
+			terms = append(terms, term)
+		case keep[term.ID]:
+			terms = append(terms, term)
+			result.Retained = append(result.Retained, term)
+		default:
+			result.Removed = append(result.Removed, term)
+		}
+	}
+	ds.TaxonomyTerms = terms
+	return result
+}
+
+// newTaxonomyImport creates the Taxonomy record with a name-derived stable ID
+func newTaxonomyImport(name, description string, opts *TaxonomyImportOptions) *TaxonomyImport {
+	if opts != nil && opts.Name != "" {
+		name = opts.Name
+	}
+	if opts != nil && opts.Description != "" {
+		description = opts.Description
+	}
+
+	var factory *Factory
+	if opts != nil {
+		factory = opts.Factory
+	}
+	// the names are never blank, so derivation cannot fail
+	id, _ := DeriveID(taxonomyNamespace, name, ResourceTypeTaxonomy)
+	taxonomy := Taxonomy{
+		CreatedAt:   FlexTime{factoryOr(factory).now()},
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+	if opts != nil {
+		taxonomy.URI = opts.URI
+		taxonomy.Version = opts.Version
+	}
+
+	return &TaxonomyImport{Taxonomy: taxonomy}
+}
+
+// addTerm appends a term whose ID is derived from the taxonomy ID and code
+func (imp *TaxonomyImport) addTerm(code, name, description, parentCode string, opts *TaxonomyImportOptions) {
+	language := "en"
+	if opts != nil && opts.Language != "" {
+		language = opts.Language
+	}
+	if description == "" {
+		description = name
+	}
+
+	taxonomyID := imp.Taxonomy.ID
+	taxonomyName := imp.Taxonomy.Name
+	term := TaxonomyTerm{
+		CreatedAt:   imp.Taxonomy.CreatedAt,
+		TaxonomyID:  &taxonomyID,
+		ID:          imp.termID(code),
+		Code:        &code,
+		Name:        name,
+		Description: description,
+		TaxonomyStr: &taxonomyName,
+		Language:    &language,
+	}
+	if parentCode != "" {
+		parentID := imp.termID(parentCode)
+		term.ParentID = &parentID
+	}
+	if opts != nil && opts.TermURIBase != "" {
+		termURI := opts.TermURIBase + code
+		term.TermURI = &termURI
+	}
+
+	imp.Terms = append(imp.Terms, term)
+}
+
+// termID derives a stable UUIDv5 for a code within this taxonomy; codes are
+// never blank, so derivation cannot fail
+func (imp *TaxonomyImport) termID(code string) string {
+	id, _ := DeriveID(imp.Taxonomy.ID, code, ResourceTypeTaxonomyTerm)
+	return id
+}
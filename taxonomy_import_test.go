@@ -0,0 +1,121 @@
+package hsds_types
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const airsExport = `Term Code,Term Name,Definition
+B,Basic Needs,Programs that provide basic needs
+BD,Food,
+BD-1800.2000,Food Pantries,Programs that distribute groceries
+BD-5000,Meals,
+`
+
+// termsByCode indexes imported terms by code
+func termsByCode(terms []TaxonomyTerm) map[string]TaxonomyTerm {
+	byCode := make(map[string]TaxonomyTerm)
+	for _, term := range terms {
+		byCode[*term.Code] = term
+	}
+	return byCode
+}
+
+func TestImportAIRSTaxonomy(t *testing.T) {
+	imp, err := ImportAIRSTaxonomy(strings.NewReader(airsExport), &TaxonomyImportOptions{Factory: newTestFactory(), TermURIBase: "https://211taxonomy.org/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	terms := termsByCode(imp.Terms)
+	if len(terms) != 4 {
+		t.Fatalf("terms = %d, want 4", len(terms))
+	}
+
+	// BD-1800 is absent, so the pantry term hangs off BD
+	tests := []struct{ code, parent string }{{"B", ""}, {"BD", "B"}, {"BD-1800.2000", "BD"}, {"BD-5000", "BD"}}
+	for _, tt := range tests {
+		term := terms[tt.code]
+		switch {
+		case tt.parent == "" && term.ParentID != nil:
+			t.Errorf("%s: parent = %s, want a root", tt.code, *term.ParentID)
+		case tt.parent != "" && (term.ParentID == nil || *term.ParentID != terms[tt.parent].ID):
+			t.Errorf("%s: parent = %v, want %s", tt.code, term.ParentID, tt.parent)
+		}
+	}
+	if terms["BD"].Description != "Food" || *terms["BD"].TermURI != "https://211taxonomy.org/BD" {
+		t.Errorf("BD = %q %q", terms["BD"].Description, *terms["BD"].TermURI)
+	}
+
+	again, err := ImportAIRSTaxonomy(strings.NewReader(airsExport), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Taxonomy.ID != imp.Taxonomy.ID || termsByCode(again.Terms)["BD"].ID != terms["BD"].ID {
+		t.Error("re-importing the same export changed the IDs")
+	}
+
+	if _, err := ImportAIRSTaxonomy(strings.NewReader("Name\nFood\n"), nil); err == nil {
+		t.Error("accepted an export without a code column")
+	}
+	if _, err := ImportAIRSTaxonomy(strings.NewReader("Code,Name\nBD,Food\nbd,Food\n"), nil); err == nil {
+		t.Error("accepted a duplicate code")
+	}
+}
+
+func TestImportOpenEligibilityTaxonomy(t *testing.T) {
+	data := `<services>
+		<service id="101" name="Emergency">
+			<service id="102" name="Emergency Food"/>
+		</service>
+		<service name="Housing"/>
+	</services>`
+	imp, err := ImportOpenEligibilityTaxonomy(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terms := termsByCode(imp.Terms)
+	if len(terms) != 3 || terms["housing"].Name != "Housing" {
+		t.Fatalf("terms = %+v", terms)
+	}
+	if p := terms["102"].ParentID; p == nil || *p != terms["101"].ID {
+		t.Errorf("Emergency Food parent = %v, want Emergency", p)
+	}
+}
+
+func TestUpsertTaxonomy(t *testing.T) {
+	older := NewFactory(FixedClock{testTime.Add(-24 * time.Hour)}, nil)
+	first, err := ImportAIRSTaxonomy(strings.NewReader(airsExport), &TaxonomyImportOptions{Factory: older})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := &Dataset{}
+	if result := ds.UpsertTaxonomy(first); len(result.Removed)+len(result.Retained) != 0 {
+		t.Errorf("first import retired %+v", result)
+	}
+	meals := termsByCode(first.Terms)["BD-5000"]
+	ds.Attributes = []Attribute{{ID: "a1", TaxonomyTermID: meals.ID, LinkEntity: "service", LinkID: "s1"}}
+
+	// the newer export renames Food and drops the pantry and meals terms
+	newer := "Code,Name\nB,Basic Needs\nBD,Food and Meals\n"
+	second, err := ImportAIRSTaxonomy(strings.NewReader(newer), &TaxonomyImportOptions{Factory: newTestFactory()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := ds.UpsertTaxonomy(second)
+
+	if len(result.Removed) != 1 || *result.Removed[0].Code != "BD-1800.2000" {
+		t.Errorf("removed = %+v, want the unused pantry term", result.Removed)
+	}
+	if len(result.Retained) != 1 || result.Retained[0].ID != meals.ID {
+		t.Errorf("retained = %+v, want the meals term still tagged", result.Retained)
+	}
+	terms := termsByCode(ds.TaxonomyTerms)
+	if len(ds.Taxonomies) != 1 || len(terms) != 3 {
+		t.Fatalf("taxonomies = %d, terms = %v", len(ds.Taxonomies), terms)
+	}
+	food := terms["BD"]
+	if food.Name != "Food and Meals" || !food.CreatedAt.Equal(testTime.Add(-24*time.Hour)) || !food.UpdatedAt.Equal(testTime) {
+		t.Errorf("BD = %q created %v updated %v", food.Name, food.CreatedAt, food.UpdatedAt)
+	}
+}
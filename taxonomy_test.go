@@ -0,0 +1,105 @@
+package hsds_types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// taxonomyTerms returns Food > {Food Pantries, Meals > Hot Meals} and Legal
+func taxonomyTerms() []TaxonomyTerm {
+	food, meals := "food", "meals"
+	return []TaxonomyTerm{
+		{ID: "hot", Name: "Hot Meals", ParentID: &meals},
+		{ID: "pantry", Name: "Food Pantries", Code: strPtr("BD-1800.2000"), ParentID: &food},
+		{ID: "legal", Name: "Legal Services", Code: strPtr("F")},
+		{ID: "meals", Name: "Meals", Code: strPtr("BD-5000"), ParentID: &food},
+		{ID: "food", Name: "Food", Code: strPtr("BD")},
+	}
+}
+
+// termIDs lists the IDs of terms in order
+func termIDs(terms []TaxonomyTerm) []string {
+	var ids []string
+	for _, term := range terms {
+		ids = append(ids, term.ID)
+	}
+	return ids
+}
+
+func TestTaxonomyTree(t *testing.T) {
+	tree, err := NewTaxonomyTree(taxonomyTerms())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"roots by code", termIDs(tree.Roots()), []string{"food", "legal"}},
+		{"children by code", termIDs(tree.Children("food")), []string{"pantry", "meals"}},
+		{"ancestors nearest first", termIDs(tree.Ancestors("hot")), []string{"meals", "food"}},
+		{"descendants depth first", termIDs(tree.Descendants("food")), []string{"pantry", "meals", "hot"}},
+		{"path from the root", termIDs(tree.Path("hot")), []string{"food", "meals", "hot"}},
+		{"find by name", termIDs(tree.FindByName(" hot meals ")), []string{"hot"}},
+		{"unknown path", termIDs(tree.Path("missing")), nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if tree.Depth("food") != 0 || tree.Depth("hot") != 2 || tree.Depth("missing") != -1 {
+		t.Errorf("depths = %d %d %d", tree.Depth("food"), tree.Depth("hot"), tree.Depth("missing"))
+	}
+	if tree.Term("legal") == nil || tree.Term("missing") != nil {
+		t.Error("Term lookup")
+	}
+}
+
+func TestNewTaxonomyTreeErrors(t *testing.T) {
+	a, b := "a", "b"
+	tests := []struct {
+		name    string
+		terms   []TaxonomyTerm
+		wantErr string
+	}{
+		{"duplicate IDs", []TaxonomyTerm{{ID: "a"}, {ID: "a"}}, "duplicate taxonomy term ID a"},
+		{"parent cycle", []TaxonomyTerm{{ID: "a", ParentID: &b}, {ID: "b", ParentID: &a}}, "form a cycle"},
+	}
+	for _, tt := range tests {
+		if _, err := NewTaxonomyTree(tt.terms); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	// a missing parent makes the term a root
+	tree, err := NewTaxonomyTree([]TaxonomyTerm{{ID: "orphan", ParentID: &a}})
+	if err != nil || len(tree.Roots()) != 1 {
+		t.Errorf("orphan term: %v, roots %v", err, tree.Roots())
+	}
+}
+
+func TestServicesForTerm(t *testing.T) {
+	tree, err := NewTaxonomyTree(taxonomyTerms())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := &Dataset{
+		Services: []Service{{ID: "s1", Name: "Pantry"}, {ID: "s2", Name: "Soup Kitchen"}, {ID: "s3", Name: "Clinic"}},
+		Attributes: []Attribute{
+			{ID: "a1", TaxonomyTermID: "pantry", LinkEntity: "service", LinkID: "s1"},
+			{ID: "a2", TaxonomyTermID: "hot", LinkEntity: "Service", LinkID: "s2"},
+			{ID: "a3", TaxonomyTermID: "legal", LinkEntity: "service", LinkID: "s3"},
+			{ID: "a4", TaxonomyTermID: "food", LinkEntity: "organization", LinkID: "s3"},
+		},
+	}
+	var names []string
+	for _, svc := range tree.ServicesForTerm(ds, "food") {
+		names = append(names, svc.Name)
+	}
+	if want := []string{"Pantry", "Soup Kitchen"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("services for Food = %v, want %v", names, want)
+	}
+}
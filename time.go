@@ -1,13 +1,13 @@
 package hsds_types
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
-	"reflect"
 	"time"
 )
 
-// TimeFormats contains all supported time formats across the system
+// TimeFormats contains all supported time formats across the system, in the
+// order they are tried. DefaultTimeParser registers them when the package loads.
 var TimeFormats = []string{
 	time.RFC3339,                       // 2006-01-02T15:04:05Z07:00
 	time.RFC3339Nano,                   // 2006-01-02T15:04:05.999999999Z07:00
@@ -22,7 +22,10 @@ var TimeFormats = []string{
 	"15:04:05",                         // Time only
 }
 
-// StandardTimeFields defines common time field names found in HSDS data
+// StandardTimeFields defines common time field names found in HSDS data.
+//
+// Deprecated: decoding now recognises time fields by type, see FlexTime,
+// FlexDate and FlexClock.
 var StandardTimeFields = []string{
 	"created_at",
 	"updated_at",
@@ -36,83 +39,24 @@ var StandardTimeFields = []string{
 	"closes_at",
 }
 
-// ParseTime attempts to parse a timestamp string using all supported formats
+// ParseTime attempts to parse a timestamp string using all supported formats.
+// It uses DefaultTimeParser; build a TimeParser for zone defaults, expected
+// kinds or strict parsing.
 func ParseTime(s string) (time.Time, error) {
-	for _, layout := range TimeFormats {
-		if t, err := time.Parse(layout, s); err == nil {
-			return t.UTC(), nil
-		}
-	}
-	return time.Time{}, fmt.Errorf("could not parse time: %s", s)
+	return DefaultTimeParser.Parse(s)
 }
 
-// UnmarshalJSONWithTime unmarshals JSON data into a slice of any type T
-// that contains time.Time fields, handling various time formats
+// UnmarshalJSONWithTime unmarshals a JSON array into a slice of any type T
+// whose time.Time fields may use any of TimeFormats. It decodes in a single
+// pass; use RecordDecoder directly to stream large feeds.
 func UnmarshalJSONWithTime[T any](data []byte, result *[]T) error {
-	var rawData []map[string]interface{}
-	if err := json.Unmarshal(data, &rawData); err != nil {
-		return fmt.Errorf("unmarshalling raw data: %w", err)
+	dec := NewRecordDecoder[T](bytes.NewReader(data))
+	*result = []T{}
+	for dec.Next() {
+		*result = append(*result, dec.Record())
 	}
-
-	*result = make([]T, len(rawData))
-
-	for i, raw := range rawData {
-		convertTimeFields(raw)
-
-		jsonData, err := json.Marshal(raw)
-		if err != nil {
-			return fmt.Errorf("marshalling processed data: %w", err)
-		}
-
-		if err := json.Unmarshal(jsonData, &(*result)[i]); err != nil {
-			return fmt.Errorf("unmarshalling to target type: %w", err)
-		}
+	if err := dec.Err(); err != nil {
+		return fmt.Errorf("unmarshalling to target type: %w", err)
 	}
-
 	return nil
 }
-
-// UnmarshalMultipleJSONResponses unmarshals multiple JSON responses into a single
-// slice of type T, deduplicating by ID field
-func UnmarshalMultipleJSONResponses[T any](responses [][]byte) ([]T, error) {
-	uniqueItems := make(map[string]T)
-
-	for _, data := range responses {
-		var items []T
-		if err := UnmarshalJSONWithTime(data, &items); err != nil {
-			return nil, fmt.Errorf("unmarshalling response: %w", err)
-		}
-
-		for _, item := range items {
-			id := reflect.ValueOf(item).FieldByName("ID").String()
-			uniqueItems[id] = item
-		}
-	}
-
-	result := make([]T, 0, len(uniqueItems))
-	for _, item := range uniqueItems {
-		result = append(result, item)
-	}
-
-	return result, nil
-}
-
-// convertTimeFields recursively processes a map and converts any time string fields
-// to proper time.Time format
-func convertTimeFields(data map[string]interface{}) {
-	for key, value := range data {
-		switch v := value.(type) {
-		case string:
-			for _, tf := range StandardTimeFields {
-				if key == tf {
-					if t, err := ParseTime(v); err == nil {
-						data[key] = t
-					}
-					break
-				}
-			}
-		case map[string]interface{}:
-			convertTimeFields(v)
-		}
-	}
-}
@@ -0,0 +1,252 @@
+package hsds_types
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeKind is what a time value holds: a full timestamp, a calendar date or
+// a time of day
+type TimeKind string
+
+// TimeKind values; the empty kind accepts any of them
+const (
+	TimeKindDateTime  TimeKind = "datetime"
+	TimeKindDate      TimeKind = "date"
+	TimeKindTimeOfDay TimeKind = "time_of_day"
+)
+
+// TimeFieldKinds are the expected kinds of the HSDS time fields, by JSON name
+var TimeFieldKinds = map[string]TimeKind{
+	"created_at":       TimeKindDateTime,
+	"updated_at":       TimeKindDateTime,
+	"last_modified":    TimeKindDateTime,
+	"updated":          TimeKindDateTime,
+	"assured_date":     TimeKindDate,
+	"valid_from":       TimeKindDate,
+	"valid_to":         TimeKindDate,
+	"dtstart":          TimeKindDate,
+	"until":            TimeKindDate,
+	"last_action_date": TimeKindDate,
+	"opens_at":         TimeKindTimeOfDay,
+	"closes_at":        TimeKindTimeOfDay,
+}
+
+// TimeParserOptions contains optional settings for NewTimeParser
+type TimeParserOptions struct {
+	// Formats are the layouts to try, in order; defaults to TimeFormats
+	Formats []string
+	// Location applies to inputs without a zone; defaults to UTC
+	Location *time.Location
+	// Strict rejects ambiguous inputs instead of assuming UTC, midnight or year 0
+	Strict bool
+	// Fields are the expected kinds by field name; defaults to TimeFieldKinds
+	Fields map[string]TimeKind
+}
+
+// TimeParser parses timestamps, dates and times of day against a registered
+// list of layouts. Zone-less inputs are read in Location. Set Location,
+// Strict and Fields before sharing a parser between goroutines; Register is
+// safe to call at any time.
+type TimeParser struct {
+	Location *time.Location
+	Strict   bool
+	Fields   map[string]TimeKind
+
+	mu      sync.RWMutex
+	layouts []timeLayout
+}
+
+// timeLayout is a registered layout and what it can express
+type timeLayout struct {
+	layout string
+	kind   TimeKind
+	zoned  bool
+}
+
+// DefaultTimeParser is the lenient, UTC parser behind ParseTime and the
+// flexible time types. It registers TimeFormats when the package loads; use
+// DefaultTimeParser.Register to accept more layouts.
+var DefaultTimeParser = NewTimeParser(nil)
+
+// TimeParseError reports an input that matched no layout, or matched one but
+// was rejected for its kind or as ambiguous
+type TimeParseError struct {
+	Input    string
+	Field    string
+	Expected TimeKind
+	// Layout is the layout that matched a rejected input
+	Layout string
+	// Tried lists the layouts attempted, in order
+	Tried  []string
+	Reason string
+}
+
+// Error describes the input, the reason and the layouts tried
+func (e *TimeParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "parsing time %q", e.Input)
+	if e.Field != "" {
+		fmt.Fprintf(&b, " for %s", e.Field)
+	}
+	if e.Expected != "" {
+		fmt.Fprintf(&b, " as %s", e.Expected)
+	}
+	b.WriteString(": " + e.Reason)
+	if e.Layout != "" {
+		fmt.Fprintf(&b, " (matched layout %q)", e.Layout)
+	}
+	if len(e.Tried) > 0 {
+		fmt.Fprintf(&b, "; tried layouts %q", e.Tried)
+	}
+	return b.String()
+}
+
+// NewTimeParser creates a TimeParser from opts, which may be nil
+func NewTimeParser(opts *TimeParserOptions) *TimeParser {
+	if opts == nil {
+		opts = &TimeParserOptions{}
+	}
+	p := &TimeParser{
+		Location: opts.Location,
+		Strict:   opts.Strict,
+		Fields:   opts.Fields,
+	}
+	if p.Fields == nil {
+		p.Fields = TimeFieldKinds
+	}
+	formats := opts.Formats
+	if formats == nil {
+		formats = TimeFormats
+	}
+	for _, layout := range formats {
+		p.Register(layout)
+	}
+	return p
+}
+
+// Register appends a layout to the formats the parser tries, ignoring
+// layouts already registered
+func (p *TimeParser) Register(layout string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, l := range p.layouts {
+		if l.layout == layout {
+			return
+		}
+	}
+	p.layouts = append(p.layouts, classifyLayout(layout))
+}
+
+// Formats returns the registered layouts in the order they are tried
+func (p *TimeParser) Formats() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	formats := make([]string, len(p.layouts))
+	for i, l := range p.layouts {
+		formats[i] = l.layout
+	}
+	return formats
+}
+
+// Parse parses s with no expected kind
+func (p *TimeParser) Parse(s string) (time.Time, error) {
+	return p.parse(s, "", "")
+}
+
+// ParseAs parses s as the expected kind. Dates are midnight UTC and times of
+// day fall on the zero date, both as written in the input.
+func (p *TimeParser) ParseAs(s string, kind TimeKind) (time.Time, error) {
+	return p.parse(s, "", kind)
+}
+
+// ParseField parses s with the kind expected for field in Fields
+func (p *TimeParser) ParseField(field, s string) (time.Time, error) {
+	return p.parse(s, field, p.Fields[field])
+}
+
+// parse tries each layout in order and checks the first match against expected
+func (p *TimeParser) parse(s, field string, expected TimeKind) (time.Time, error) {
+	p.mu.RLock()
+	layouts := p.layouts
+	p.mu.RUnlock()
+
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	tried := make([]string, 0, len(layouts))
+	for _, l := range layouts {
+		tried = append(tried, l.layout)
+		var t time.Time
+		var err error
+		if l.zoned {
+			t, err = time.Parse(l.layout, s)
+		} else {
+			t, err = time.ParseInLocation(l.layout, s, loc)
+		}
+		if err != nil {
+			continue
+		}
+		if reason := p.reject(l, expected); reason != "" {
+			return time.Time{}, &TimeParseError{Input: s, Field: field, Expected: expected, Layout: l.layout, Tried: tried, Reason: reason}
+		}
+		return normalizeTime(t, expected), nil
+	}
+	return time.Time{}, &TimeParseError{Input: s, Field: field, Expected: expected, Tried: tried, Reason: "no layout matched"}
+}
+
+// reject explains why an input parsed with l cannot stand for the expected
+// kind, or returns "" when it can
+func (p *TimeParser) reject(l timeLayout, expected TimeKind) string {
+	switch {
+	case l.kind == TimeKindTimeOfDay && expected != "" && expected != TimeKindTimeOfDay:
+		return "input has no date"
+	case l.kind == TimeKindDate && expected == TimeKindTimeOfDay:
+		return "input has no time of day"
+	case !p.Strict:
+		return ""
+	case l.kind == TimeKindTimeOfDay && expected == "":
+		return "a time of day without a date is ambiguous"
+	case l.kind == TimeKindDate && (expected == TimeKindDateTime || expected == ""):
+		return "a date without a time is ambiguous"
+	case l.kind == TimeKindDateTime && expected == TimeKindDate:
+		return "input has a time of day where a date is expected"
+	case l.kind == TimeKindDateTime && expected == TimeKindTimeOfDay:
+		return "input has a date where a time of day is expected"
+	case l.kind == TimeKindDateTime && !l.zoned && p.Location == nil:
+		return "input has no time zone and no default location is set"
+	}
+	return ""
+}
+
+// normalizeTime reduces t to the expected kind; timestamps are returned in UTC
+func normalizeTime(t time.Time, kind TimeKind) time.Time {
+	switch kind {
+	case TimeKindDate:
+		return toDate(t)
+	case TimeKindTimeOfDay:
+		return toClock(t)
+	}
+	return t.UTC()
+}
+
+// classifyLayout works out whether a layout holds a date, a clock and a zone.
+// A literal "+00" suffix pins the zone to UTC.
+func classifyLayout(layout string) timeLayout {
+	hasDate := strings.Contains(layout, "2006") || strings.Contains(layout, "Jan") || strings.Contains(layout, "01/02")
+	hasClock := strings.Contains(layout, "15") || strings.Contains(layout, "03") || strings.Contains(layout, ":04")
+	zoned := strings.Contains(layout, "Z07") || strings.Contains(layout, "-07") || strings.Contains(layout, "MST") || strings.HasSuffix(layout, "+00")
+
+	l := timeLayout{layout: layout, kind: TimeKindDateTime, zoned: zoned}
+	switch {
+	case hasDate && !hasClock:
+		l.kind = TimeKindDate
+	case hasClock && !hasDate:
+		l.kind = TimeKindTimeOfDay
+	}
+	return l
+}
@@ -0,0 +1,77 @@
+package hsds_types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeParserParse(t *testing.T) {
+	strict := NewTimeParser(&TimeParserOptions{Strict: true})
+	tests := []struct {
+		name    string
+		parser  *TimeParser
+		input   string
+		kind    TimeKind
+		want    time.Time
+		wantErr bool
+	}{
+		{"RFC3339", DefaultTimeParser, "2024-03-10T09:30:00-08:00", "", time.Date(2024, 3, 10, 17, 30, 0, 0, time.UTC), false},
+		{"lenient date only", DefaultTimeParser, "2024-03-10", "", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), false},
+		{"lenient date as datetime", DefaultTimeParser, "2024-03-10", TimeKindDateTime, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), false},
+		{"date from timestamp", DefaultTimeParser, "2024-03-10T23:30:00Z", TimeKindDate, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), false},
+		{"time of day", DefaultTimeParser, "09:30:00", TimeKindTimeOfDay, time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC), false},
+		{"time of day as date", DefaultTimeParser, "09:30:00", TimeKindDate, time.Time{}, true},
+		{"date as time of day", DefaultTimeParser, "2024-03-10", TimeKindTimeOfDay, time.Time{}, true},
+		{"unknown layout", DefaultTimeParser, "next tuesday", "", time.Time{}, true},
+		{"strict zoned timestamp", strict, "2024-03-10T09:30:00Z", "", time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC), false},
+		{"strict date only", strict, "2024-03-10", "", time.Time{}, true},
+		{"strict date as datetime", strict, "2024-03-10", TimeKindDateTime, time.Time{}, true},
+		{"strict date as date", strict, "2024-03-10", TimeKindDate, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), false},
+		{"strict time of day only", strict, "09:30:00", "", time.Time{}, true},
+		{"strict timestamp as date", strict, "2024-03-10T09:30:00Z", TimeKindDate, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.parser.ParseAs(tt.input, tt.kind)
+		if tt.wantErr {
+			var perr *TimeParseError
+			if !errors.As(err, &perr) {
+				t.Errorf("%s: ParseAs(%q) error = %v, want a TimeParseError", tt.name, tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ParseAs(%q): %v", tt.name, tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: ParseAs(%q) = %v, want %v", tt.name, tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTimeParserParseField(t *testing.T) {
+	strict := NewTimeParser(&TimeParserOptions{Strict: true})
+	if _, err := strict.ParseField("created_at", "2024-03-10"); err == nil {
+		t.Error("strict parser accepted a date-only created_at")
+	}
+	got, err := strict.ParseField("assured_date", "2024-03-10")
+	if err != nil || !got.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("assured_date = %v, %v", got, err)
+	}
+}
+
+func TestTimeParserRegister(t *testing.T) {
+	p := NewTimeParser(&TimeParserOptions{Formats: []string{time.RFC3339}})
+	if _, err := p.Parse("10 Mar 2024"); err == nil {
+		t.Fatal("parser accepted an unregistered layout")
+	}
+	p.Register("2 Jan 2006")
+	p.Register("2 Jan 2006")
+	if n := len(p.Formats()); n != 2 {
+		t.Errorf("formats = %d, want 2", n)
+	}
+	if _, err := p.Parse("10 Mar 2024"); err != nil {
+		t.Errorf("Parse after Register: %v", err)
+	}
+}
@@ -3,12 +3,10 @@
 
 package hsds_types
 
-import "time"
-
 // // -- HSDS Definitions -- ////
 type Organization struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ParentOrganizationID *string `json:"parent_organization_id,omitempty" gorm:"type:varchar(250);column:parent_organization_id"`
@@ -29,8 +27,8 @@ type Organization struct {
 }
 
 type OrganizationIdentifier struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	OrganizationID string       `json:"organization_id" gorm:"type:varchar(250);not null;foreignKey:OrganizationID;references:ID" validate:"required"`
@@ -44,8 +42,8 @@ type OrganizationIdentifier struct {
 }
 
 type URL struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	OrganizationID *string      `json:"organization_id,omitempty" gorm:"type:varchar(250);foreignKey:OrganizationID;references:ID"`
@@ -60,8 +58,8 @@ type URL struct {
 }
 
 type Funding struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	OrganizationID *string      `json:"organization_id,omitempty" gorm:"type:varchar(250);foreignKey:OrganizationID;references:ID"`
@@ -75,8 +73,8 @@ type Funding struct {
 }
 
 type Unit struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Unit Data
 	ID         string  `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
@@ -87,8 +85,8 @@ type Unit struct {
 }
 
 type Program struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	OrganizationID string       `json:"organization_id" gorm:"type:varchar(250);not null;uniqueIndex;foreignKey:OrganizationID;references:ID" validate:"required"`
@@ -102,8 +100,8 @@ type Program struct {
 }
 
 type Service struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	OrganizationID string       `json:"organization_id" gorm:"type:varchar(250);not null;foreignKey:OrganizationID;references:ID" validate:"required"`
@@ -128,15 +126,15 @@ type Service struct {
 	EligibilityDescription *string           `json:"eligibility_description,omitempty" gorm:"type:text"`
 	MinimumAge             *float64          `json:"minimum_age,omitempty" gorm:"type:numeric"`
 	MaximumAge             *float64          `json:"maximum_age,omitempty" gorm:"type:numeric"`
-	AssuredDate            *time.Time        `json:"assured_date,omitempty" gorm:"type:date"`
+	AssuredDate            *FlexDate         `json:"assured_date,omitempty" gorm:"type:date"`
 	AssurerEmail           *string           `json:"assurer_email,omitempty" gorm:"type:text"`
 	Licenses               *string           `json:"licenses,omitempty" gorm:"type:text"` // Deprecated
 	Alert                  *string           `json:"alert,omitempty" gorm:"type:text"`
 }
 
 type ServiceArea struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID           *string           `json:"service_id,omitempty" gorm:"type:varchar(250);foreignKey:ServiceID;references:ID"`
@@ -154,8 +152,8 @@ type ServiceArea struct {
 }
 
 type ServiceAtLocation struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID  string   `json:"service_id" gorm:"type:varchar(250);not null;foreignKey:ServiceID;references:ID" validate:"required"`
@@ -169,8 +167,8 @@ type ServiceAtLocation struct {
 }
 
 type Location struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 	// Foreign Key Relationships
 	OrganizationID *string       `json:"organization_id,omitempty" gorm:"type:varchar(250);column:organization_id;foreignKey:id"`
 	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID;references:ID"`
@@ -189,8 +187,8 @@ type Location struct {
 }
 
 type Address struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	LocationID *string  `json:"location_id,omitempty" gorm:"type:varchar(250);foreignKey:LocationID;references:ID"`
@@ -210,8 +208,8 @@ type Address struct {
 }
 
 type RequiredDocument struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at,omitempty"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at,omitempty"`
 
 	// Foreign Key Relationships
 	ServiceID *string `json:"service_id,omitempty" gorm:"type:varchar(250);foreignKey:ServiceID;references:ID"`
@@ -224,8 +222,8 @@ type RequiredDocument struct {
 }
 
 type Language struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID  *string  `json:"service_id,omitempty" gorm:"type:varchar(250);foreignKey:ServiceID;references:ID"`
@@ -243,8 +241,8 @@ type Language struct {
 }
 
 type Accessibility struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationship
 	LocationID *string  `json:"location_id,omitempty" gorm:"type:varchar(250);foreignKey:LocationID;references:ID"`
@@ -258,8 +256,8 @@ type Accessibility struct {
 }
 
 type Attribute struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at,omitempty"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at,omitempty"`
 
 	// Foreign Key Relationship
 	TaxonomyTermID string       `json:"taxonomy_term_id" gorm:"type:varchar(250);not null;foreignKey:TaxonomyTermID;references:ID" validate:"required"`
@@ -275,8 +273,8 @@ type Attribute struct {
 }
 
 type Taxonomy struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Taxonomy Data
 	ID          string  `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
@@ -287,8 +285,8 @@ type Taxonomy struct {
 }
 
 type TaxonomyTerm struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	TaxonomyID *string        `json:"taxonomy_id,omitempty" gorm:"type:varchar(250);foreignKey:TaxonomyID;references:ID"`
@@ -308,8 +306,8 @@ type TaxonomyTerm struct {
 }
 
 type Contact struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at,omitempty"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at,omitempty"`
 
 	// Foreign Key Relationships
 	OrganizationID *string      `json:"organization_id,omitempty" gorm:"type:varchar(250);foreignKey:OrganizationID;references:ID"`
@@ -333,8 +331,8 @@ type Contact struct {
 }
 
 type Phone struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	LocationID *string  `json:"location_id,omitempty" gorm:"type:varchar(250);foreignKey:LocationID;references:ID"`
@@ -353,16 +351,16 @@ type Phone struct {
 	ServiceAtLocation   ServiceAtLocation `gorm:"foreignKey:ServiceAtLocationID;references:ID" json:"-"`
 
 	// Phone Data
-	ID          string   `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
-	Number      string   `json:"number" gorm:"type:text;not null" validate:"required"`
-	Extension   *float64 `json:"extension,omitempty" gorm:"type:numeric"`
-	Type        *string  `json:"type,omitempty" gorm:"type:text"`
-	Description *string  `json:"description,omitempty" gorm:"type:text"`
+	ID          string          `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
+	Number      string          `json:"number" gorm:"type:text;not null" validate:"required"`
+	Extension   *PhoneExtension `json:"extension,omitempty" gorm:"type:text"`
+	Type        *string         `json:"type,omitempty" gorm:"type:text"`
+	Description *string         `json:"description,omitempty" gorm:"type:text"`
 }
 
 type Schedule struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID *string `json:"service_id,omitempty" gorm:"type:varchar(250);foreignKey:ServiceID;references:ID"`
@@ -376,11 +374,11 @@ type Schedule struct {
 
 	// Schedule Data
 	ID            string            `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
-	ValidFrom     *time.Time        `json:"valid_from,omitempty" gorm:"type:date"`
-	ValidTo       *time.Time        `json:"valid_to,omitempty" gorm:"type:date"`
-	DTStart       *time.Time        `json:"dtstart,omitempty" gorm:"type:date;column:dtstart"`
+	ValidFrom     *FlexDate         `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo       *FlexDate         `json:"valid_to,omitempty" gorm:"type:date"`
+	DTStart       *FlexDate         `json:"dtstart,omitempty" gorm:"type:date;column:dtstart"`
 	Timezone      *float64          `json:"timezone,omitempty" gorm:"type:numeric"`
-	Until         *time.Time        `json:"until,omitempty" gorm:"type:date"`
+	Until         *FlexDate         `json:"until,omitempty" gorm:"type:date"`
 	Count         *int              `json:"count,omitempty" gorm:"type:numeric"`
 	Wkst          *ScheduleWkstEnum `json:"wkst,omitempty" gorm:"type:schedule_wkst_enum"`
 	Freq          *ScheduleFreqEnum `json:"freq,omitempty" gorm:"type:schedule_freq_enum"`
@@ -390,16 +388,16 @@ type Schedule struct {
 	Bymonthday    *string           `json:"bymonthday,omitempty" gorm:"type:text"`
 	Byyearday     *string           `json:"byyearday,omitempty" gorm:"type:text"`
 	Description   *string           `json:"description,omitempty" gorm:"type:text"`
-	OpensAt       *time.Time        `json:"opens_at,omitempty" gorm:"type:time without time zone"`
-	ClosesAt      *time.Time        `json:"closes_at,omitempty" gorm:"type:time without time zone"`
+	OpensAt       *FlexClock        `json:"opens_at,omitempty" gorm:"type:time without time zone"`
+	ClosesAt      *FlexClock        `json:"closes_at,omitempty" gorm:"type:time without time zone"`
 	ScheduleLink  *string           `json:"schedule_link,omitempty" gorm:"type:text"`
 	AttendingType *string           `json:"attending_type,omitempty" gorm:"type:text"`
 	Notes         *string           `json:"notes,omitempty" gorm:"type:text"`
 }
 
 type ServiceCapacity struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID string  `json:"service_id" gorm:"type:varchar(250);not null;foreignKey:ServiceID;references:ID" validate:"required"`
@@ -409,53 +407,53 @@ type ServiceCapacity struct {
 	Unit   Unit   `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 
 	// Service Capacity Data
-	ID          string    `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
-	Available   float64   `json:"available" gorm:"type:numeric;not null" validate:"required"`
-	Maximum     *float64  `json:"maximum,omitempty" gorm:"type:numeric"`
-	Description *string   `json:"description,omitempty" gorm:"type:text"`
-	Updated     time.Time `json:"updated" gorm:"type:timestamp;not null" validate:"required"`
+	ID          string   `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
+	Available   float64  `json:"available" gorm:"type:numeric;not null" validate:"required"`
+	Maximum     *float64 `json:"maximum,omitempty" gorm:"type:numeric"`
+	Description *string  `json:"description,omitempty" gorm:"type:text"`
+	Updated     FlexTime `json:"updated" gorm:"type:timestamp;not null" validate:"required"`
 }
 
 type CostOption struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Foreign Key Relationships
 	ServiceID string  `json:"service_id" gorm:"type:varchar(250);not null;foreignKey:ServiceID;references:ID" validate:"required"`
 	Service   Service `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 
 	// CostOption Data
-	ID                string     `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
-	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
-	ValidTo           *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
-	Option            *string    `json:"option,omitempty" gorm:"type:text"`
-	Currency          *string    `json:"currency,omitempty" gorm:"type:text"`
-	Amount            *float64   `json:"amount,omitempty" gorm:"type:numeric"`
-	AmountDescription *string    `json:"amount_description,omitempty" gorm:"type:text"`
+	ID                string    `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
+	ValidFrom         *FlexDate `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo           *FlexDate `json:"valid_to,omitempty" gorm:"type:date"`
+	Option            *string   `json:"option,omitempty" gorm:"type:text"`
+	Currency          *string   `json:"currency,omitempty" gorm:"type:text"`
+	Amount            *float64  `json:"amount,omitempty" gorm:"type:numeric"`
+	AmountDescription *string   `json:"amount_description,omitempty" gorm:"type:text"`
 }
 
 type Metadata struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// Resource Reference
 	ResourceID string `json:"resource_id" gorm:"type:text;not null" validate:"required"`
 
 	// Metadata Data
-	ID               string    `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
-	CallID           string    `json:"call_fk" validate:"required"`
-	ResourceType     string    `json:"resource_type" gorm:"type:text;not null" validate:"required"`
-	LastActionDate   time.Time `json:"last_action_date" gorm:"type:date;not null" validate:"required"`
-	LastActionType   string    `json:"last_action_type" gorm:"type:text;not null" validate:"required"`
-	FieldName        string    `json:"field_name" gorm:"type:text;not null" validate:"required"`
-	PreviousValue    string    `json:"previous_value" gorm:"type:text;not null" validate:"required"`
-	ReplacementValue string    `json:"replacement_value" gorm:"type:text;not null" validate:"required"`
-	UpdatedBy        string    `json:"updated_by" gorm:"type:text;not null" validate:"required"`
+	ID               string                     `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
+	CallID           string                     `json:"call_fk" validate:"required"`
+	ResourceType     MetadataResourceTypeEnum   `json:"resource_type" gorm:"type:text;not null" validate:"required"`
+	LastActionDate   FlexDate                   `json:"last_action_date" gorm:"type:date;not null" validate:"required"`
+	LastActionType   MetadataLastActionTypeEnum `json:"last_action_type" gorm:"type:text;not null" validate:"required"`
+	FieldName        string                     `json:"field_name" gorm:"type:text;not null" validate:"required"`
+	PreviousValue    string                     `json:"previous_value" gorm:"type:text;not null" validate:"required"`
+	ReplacementValue string                     `json:"replacement_value" gorm:"type:text;not null" validate:"required"`
+	UpdatedBy        string                     `json:"updated_by" gorm:"type:text;not null" validate:"required"`
 }
 
 type MetaTableDescription struct {
-	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp" json:"updated_at"`
+	CreatedAt FlexTime `gorm:"type:timestamp" json:"created_at"`
+	UpdatedAt FlexTime `gorm:"type:timestamp" json:"updated_at"`
 
 	// MetaTableDescription Data
 	ID           string  `json:"id" gorm:"type:varchar(250);primaryKey;not null" validate:"required"`
@@ -482,6 +480,10 @@ type ScheduleFreqEnum string
 type ScheduleWkstEnum string
 type ServiceStatusEnum string
 type ExtentTypeEnum string
+type PhoneTypeEnum string
+type LinkEntityEnum string
+type MetadataResourceTypeEnum string
+type MetadataLastActionTypeEnum string
 
 // AddressAddressTypeEnum values
 const (
@@ -529,3 +531,76 @@ const (
 	ExtentTypeKML      ExtentTypeEnum = "kml"
 	ExtentTypeText     ExtentTypeEnum = "text"
 )
+
+// PhoneTypeEnum values
+const (
+	PhoneTypeVoice     PhoneTypeEnum = "voice"
+	PhoneTypeFax       PhoneTypeEnum = "fax"
+	PhoneTypeText      PhoneTypeEnum = "text"
+	PhoneTypeCell      PhoneTypeEnum = "cell"
+	PhoneTypeVideo     PhoneTypeEnum = "video"
+	PhoneTypePager     PhoneTypeEnum = "pager"
+	PhoneTypeTextphone PhoneTypeEnum = "textphone"
+)
+
+// LinkEntityEnum values, one per HSDS table an Attribute can link to
+const (
+	LinkEntityOrganization           LinkEntityEnum = "organization"
+	LinkEntityOrganizationIdentifier LinkEntityEnum = "organization_identifier"
+	LinkEntityURL                    LinkEntityEnum = "url"
+	LinkEntityFunding                LinkEntityEnum = "funding"
+	LinkEntityUnit                   LinkEntityEnum = "unit"
+	LinkEntityProgram                LinkEntityEnum = "program"
+	LinkEntityService                LinkEntityEnum = "service"
+	LinkEntityServiceArea            LinkEntityEnum = "service_area"
+	LinkEntityServiceAtLocation      LinkEntityEnum = "service_at_location"
+	LinkEntityLocation               LinkEntityEnum = "location"
+	LinkEntityAddress                LinkEntityEnum = "address"
+	LinkEntityRequiredDocument       LinkEntityEnum = "required_document"
+	LinkEntityLanguage               LinkEntityEnum = "language"
+	LinkEntityAccessibility          LinkEntityEnum = "accessibility"
+	LinkEntityTaxonomy               LinkEntityEnum = "taxonomy"
+	LinkEntityTaxonomyTerm           LinkEntityEnum = "taxonomy_term"
+	LinkEntityContact                LinkEntityEnum = "contact"
+	LinkEntityPhone                  LinkEntityEnum = "phone"
+	LinkEntitySchedule               LinkEntityEnum = "schedule"
+	LinkEntityServiceCapacity        LinkEntityEnum = "service_capacity"
+	LinkEntityCostOption             LinkEntityEnum = "cost_option"
+)
+
+// MetadataResourceTypeEnum values, one per HSDS table
+const (
+	ResourceTypeOrganization           MetadataResourceTypeEnum = "organization"
+	ResourceTypeOrganizationIdentifier MetadataResourceTypeEnum = "organization_identifier"
+	ResourceTypeURL                    MetadataResourceTypeEnum = "url"
+	ResourceTypeFunding                MetadataResourceTypeEnum = "funding"
+	ResourceTypeUnit                   MetadataResourceTypeEnum = "unit"
+	ResourceTypeProgram                MetadataResourceTypeEnum = "program"
+	ResourceTypeService                MetadataResourceTypeEnum = "service"
+	ResourceTypeServiceArea            MetadataResourceTypeEnum = "service_area"
+	ResourceTypeServiceAtLocation      MetadataResourceTypeEnum = "service_at_location"
+	ResourceTypeLocation               MetadataResourceTypeEnum = "location"
+	ResourceTypeAddress                MetadataResourceTypeEnum = "address"
+	ResourceTypeRequiredDocument       MetadataResourceTypeEnum = "required_document"
+	ResourceTypeLanguage               MetadataResourceTypeEnum = "language"
+	ResourceTypeAccessibility          MetadataResourceTypeEnum = "accessibility"
+	ResourceTypeAttribute              MetadataResourceTypeEnum = "attribute"
+	ResourceTypeTaxonomy               MetadataResourceTypeEnum = "taxonomy"
+	ResourceTypeTaxonomyTerm           MetadataResourceTypeEnum = "taxonomy_term"
+	ResourceTypeContact                MetadataResourceTypeEnum = "contact"
+	ResourceTypePhone                  MetadataResourceTypeEnum = "phone"
+	ResourceTypeSchedule               MetadataResourceTypeEnum = "schedule"
+	ResourceTypeServiceCapacity        MetadataResourceTypeEnum = "service_capacity"
+	ResourceTypeCostOption             MetadataResourceTypeEnum = "cost_option"
+	ResourceTypeMetadata               MetadataResourceTypeEnum = "metadata"
+	ResourceTypeMetaTableDescription   MetadataResourceTypeEnum = "meta_table_description"
+)
+
+// MetadataLastActionTypeEnum values
+const (
+	ActionTypeCreate MetadataLastActionTypeEnum = "create"
+	ActionTypeUpdate MetadataLastActionTypeEnum = "update"
+	ActionTypeDelete MetadataLastActionTypeEnum = "delete"
+	ActionTypeMerge  MetadataLastActionTypeEnum = "merge"
+	ActionTypeVerify MetadataLastActionTypeEnum = "verify"
+)